@@ -0,0 +1,277 @@
+/*
+Copyright 2014 Google Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+)
+
+// Registry is the persistence layer backing REST.
+type Registry interface {
+	ListEvents(ctx api.Context) (*api.EventList, error)
+	WatchEvents(ctx api.Context, resourceVersion string) (watch.Interface, error)
+	GetEvent(ctx api.Context, name string) (*api.Event, error)
+	CreateEvent(ctx api.Context, event *api.Event) error
+	UpdateEvent(ctx api.Context, event *api.Event) error
+	DeleteEvent(ctx api.Context, name string) error
+}
+
+// REST implements the RESTStorage interface for events, matching on the label
+// and field sets returned by getAttrs.
+type REST struct {
+	registry Registry
+}
+
+// NewREST returns a new REST for events backed by registry.
+func NewREST(registry Registry) *REST {
+	return &REST{registry: registry}
+}
+
+func (*REST) New() runtime.Object {
+	return &api.Event{}
+}
+
+func (*REST) NewList() runtime.Object {
+	return &api.EventList{}
+}
+
+func (rest *REST) Get(ctx api.Context, name string) (runtime.Object, error) {
+	return rest.registry.GetEvent(ctx, name)
+}
+
+func (rest *REST) Create(ctx api.Context, obj runtime.Object) (<-chan apiserver.RESTResult, error) {
+	event, ok := obj.(*api.Event)
+	if !ok {
+		return nil, fmt.Errorf("not an event: %#v", obj)
+	}
+	if len(api.NamespaceValue(ctx)) > 0 && event.Namespace != "" && event.Namespace != api.NamespaceValue(ctx) {
+		return nil, fmt.Errorf("event.namespace does not match the provided context")
+	}
+	api.FillObjectMetaSystemFields(ctx, &event.ObjectMeta)
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if err := rest.registry.CreateEvent(ctx, event); err != nil {
+			return nil, err
+		}
+		return event, nil
+	}), nil
+}
+
+func (rest *REST) Update(ctx api.Context, obj runtime.Object) (<-chan apiserver.RESTResult, error) {
+	return nil, fmt.Errorf("events may not be updated")
+}
+
+func (rest *REST) Delete(ctx api.Context, name string) (<-chan apiserver.RESTResult, error) {
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		return &api.Status{Status: api.StatusSuccess}, rest.registry.DeleteEvent(ctx, name)
+	}), nil
+}
+
+func (rest *REST) Watch(ctx api.Context, label, field labels.Selector, resourceVersion string) (watch.Interface, error) {
+	return rest.registry.WatchEvents(ctx, resourceVersion)
+}
+
+// List returns every Event in the registry whose label and field sets (as
+// computed by getAttrs) match the given selectors, additionally applying any
+// timeComparisons (see ParseFieldSelector) that the equality-only
+// labels.Selector in field cannot express.
+func (rest *REST) List(ctx api.Context, label, field labels.Selector, timeComparisons ...TimeComparison) (runtime.Object, error) {
+	all, err := rest.registry.ListEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := []api.Event{}
+	for i := range all.Items {
+		event := &all.Items[i]
+		eventLabels, eventFields, err := rest.getAttrs(event)
+		if err != nil {
+			return nil, err
+		}
+		if !label.Matches(eventLabels) || !field.Matches(eventFields) {
+			continue
+		}
+		if !matchesTimeComparisons(event, timeComparisons) {
+			continue
+		}
+		matched = append(matched, *event)
+	}
+	return &api.EventList{Items: matched}, nil
+}
+
+// TimeComparison is a single "field op RFC3339-timestamp" predicate, e.g.
+// lastTimestamp>=2015-01-01T00:00:00Z, extracted from a query string by
+// ParseFieldSelector because the shared labels.Selector grammar only supports
+// "=" and "!=".
+type TimeComparison struct {
+	Field string
+	Op    string // one of ">=", "<=", ">", "<"
+	Value util.Time
+}
+
+func matchesTimeComparisons(event *api.Event, comparisons []TimeComparison) bool {
+	for _, c := range comparisons {
+		var ts util.Time
+		switch c.Field {
+		case "firstTimestamp":
+			ts = event.FirstTimestamp
+		case "lastTimestamp":
+			ts = event.LastTimestamp
+		default:
+			continue
+		}
+		switch c.Op {
+		case ">=":
+			if ts.Time.Before(c.Value.Time) {
+				return false
+			}
+		case "<=":
+			if ts.Time.After(c.Value.Time) {
+				return false
+			}
+		case ">":
+			if !ts.Time.After(c.Value.Time) {
+				return false
+			}
+		case "<":
+			if !ts.Time.Before(c.Value.Time) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// timeComparisonOperators are checked in this order so that ">=" is matched
+// before the ">" it contains.
+var timeComparisonOperators = []string{">=", "<=", ">", "<"}
+
+// timeComparisonFields are the only fields ParseFieldSelector will treat as
+// an ordered comparison; any other field containing an operator character is
+// left for labels.ParseSelector, which will report it as a malformed clause.
+var timeComparisonFields = map[string]bool{
+	"firstTimestamp": true,
+	"lastTimestamp":  true,
+}
+
+// ParseFieldSelector splits a raw field selector query string into the
+// equality/inequality clauses that labels.ParseSelector already understands
+// and the ordered-comparison clauses ("lastTimestamp>=...") that it does not.
+// Callers pass the remaining clauses string to labels.ParseSelector as usual
+// and the TimeComparisons slice through to REST.List.
+func ParseFieldSelector(selector string) (equalityClauses string, comparisons []TimeComparison, err error) {
+	if len(selector) == 0 {
+		return "", nil, nil
+	}
+
+	clauses := []string{}
+	for _, clause := range strings.Split(selector, ",") {
+		matched := false
+		for _, op := range timeComparisonOperators {
+			idx := strings.Index(clause, op)
+			if idx <= 0 || !timeComparisonFields[clause[:idx]] {
+				continue
+			}
+			field := clause[:idx]
+			value := clause[idx+len(op):]
+			ts, err := parseTimestamp(value)
+			if err != nil {
+				return "", nil, fmt.Errorf("invalid timestamp %q for field %q: %v", value, field, err)
+			}
+			comparisons = append(comparisons, TimeComparison{Field: field, Op: op, Value: ts})
+			matched = true
+			break
+		}
+		if !matched {
+			clauses = append(clauses, clause)
+		}
+	}
+	return strings.Join(clauses, ","), comparisons, nil
+}
+
+func parseTimestamp(value string) (util.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return util.Time{}, err
+	}
+	return util.NewTime(t), nil
+}
+
+// severity buckets an Event's Condition/Reason into a coarse synthetic field so
+// dashboards can filter on severity=Error without string-matching Condition
+// or Reason themselves.
+func severity(event *api.Event) string {
+	switch event.Condition {
+	case "Error", "Failed":
+		return "Error"
+	case "Warning":
+		return "Warning"
+	}
+	switch event.Reason {
+	case "Failed", "FailedCreate", "FailedDelete", "BackOff":
+		return "Error"
+	}
+	return "Info"
+}
+
+// getAttrs returns the label and field sets used to index and select Events.
+// In addition to the involvedObject.* identity fields, it exposes
+// firstTimestamp/lastTimestamp/count so List can be called with time-window
+// predicates, and derives a "severity" field from Condition/Reason so callers
+// don't have to string-match.
+//
+// It does not expose involvedObject.labels.*/annotations.*: api.ObjectReference
+// only records the involved object's identity at the moment the Event was
+// created, not its labels or annotations, so there is nothing here to select
+// on without a live lookup of the referenced object by Kind/Namespace/Name -
+// which this registry, scoped to Events alone, has no way to perform.
+func (rest *REST) getAttrs(obj runtime.Object) (label labels.Set, field labels.Set, err error) {
+	event, ok := obj.(*api.Event)
+	if !ok {
+		return nil, nil, fmt.Errorf("not an event: %v", obj)
+	}
+
+	fieldSet := labels.Set{
+		"involvedObject.kind":            event.InvolvedObject.Kind,
+		"involvedObject.namespace":       event.InvolvedObject.Namespace,
+		"involvedObject.name":            event.InvolvedObject.Name,
+		"involvedObject.uid":             string(event.InvolvedObject.UID),
+		"involvedObject.apiVersion":      event.InvolvedObject.APIVersion,
+		"involvedObject.resourceVersion": event.InvolvedObject.ResourceVersion,
+		"involvedObject.fieldPath":       event.InvolvedObject.FieldPath,
+		"condition":                      event.Condition,
+		"status":                         event.Condition,
+		"reason":                         event.Reason,
+		"source":                         event.Source,
+		"severity":                       severity(event),
+		"firstTimestamp":                 event.FirstTimestamp.String(),
+		"lastTimestamp":                  event.LastTimestamp.String(),
+		"count":                          strconv.Itoa(event.Count),
+	}
+
+	return labels.Set{}, fieldSet, nil
+}
@@ -17,24 +17,91 @@ limitations under the License.
 package event
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api/testapi"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
-	"github.com/GoogleCloudPlatform/kubernetes/pkg/registry/registrytest"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/diff"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
 )
 
-type testRegistry struct {
-	*registrytest.GenericRegistry
+// fakeRegistry is a minimal in-memory Registry used to exercise REST without a
+// real etcd-backed store.
+type fakeRegistry struct {
+	Err         error
+	Events      map[string]api.Event
+	Broadcaster *watch.Broadcaster
 }
 
-func NewTestREST() (testRegistry, *REST) {
-	reg := testRegistry{registrytest.NewGeneric(nil)}
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		Events:      map[string]api.Event{},
+		Broadcaster: watch.NewBroadcaster(0, watch.WaitIfChannelFull),
+	}
+}
+
+func (r *fakeRegistry) ListEvents(ctx api.Context) (*api.EventList, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	list := &api.EventList{}
+	for _, e := range r.Events {
+		list.Items = append(list.Items, e)
+	}
+	return list, nil
+}
+
+func (r *fakeRegistry) WatchEvents(ctx api.Context, resourceVersion string) (watch.Interface, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return r.Broadcaster.Watch(), nil
+}
+
+func (r *fakeRegistry) GetEvent(ctx api.Context, name string) (*api.Event, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	event, ok := r.Events[name]
+	if !ok {
+		return nil, fmt.Errorf("event %q not found", name)
+	}
+	return &event, nil
+}
+
+func (r *fakeRegistry) CreateEvent(ctx api.Context, event *api.Event) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	r.Events[event.Name] = *event
+	r.Broadcaster.Action(watch.Added, event)
+	return nil
+}
+
+func (r *fakeRegistry) UpdateEvent(ctx api.Context, event *api.Event) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	r.Events[event.Name] = *event
+	return nil
+}
+
+func (r *fakeRegistry) DeleteEvent(ctx api.Context, name string) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	delete(r.Events, name)
+	return nil
+}
+
+func NewTestREST() (*fakeRegistry, *REST) {
+	reg := newFakeRegistry()
 	return reg, NewREST(reg)
 }
 
@@ -133,6 +200,8 @@ func TestRESTGet(t *testing.T) {
 
 func TestRESTgetAttrs(t *testing.T) {
 	_, rest := NewTestREST()
+	first := util.NewTime(util.Now().Add(-time.Hour))
+	last := util.Now()
 	eventA := &api.Event{
 		InvolvedObject: api.ObjectReference{
 			Kind:            "Pod",
@@ -143,9 +212,12 @@ func TestRESTgetAttrs(t *testing.T) {
 			ResourceVersion: "0",
 			FieldPath:       "",
 		},
-		Condition: "Tested",
-		Reason:    "ForTesting",
-		Source:    "test",
+		Condition:      "Tested",
+		Reason:         "ForTesting",
+		Source:         "test",
+		FirstTimestamp: first,
+		LastTimestamp:  last,
+		Count:          3,
 	}
 	label, field, err := rest.getAttrs(eventA)
 	if err != nil {
@@ -166,12 +238,40 @@ func TestRESTgetAttrs(t *testing.T) {
 		"status":                         "Tested",
 		"reason":                         "ForTesting",
 		"source":                         "test",
+		"severity":                       "Info",
+		"firstTimestamp":                 first.String(),
+		"lastTimestamp":                  last.String(),
+		"count":                          "3",
 	}
 	if e, a := expect, field; !reflect.DeepEqual(e, a) {
 		t.Errorf("diff: %s", util.ObjectDiff(e, a))
 	}
 }
 
+func TestRESTgetAttrsSeverity(t *testing.T) {
+	_, rest := NewTestREST()
+	table := []struct {
+		condition string
+		reason    string
+		severity  string
+	}{
+		{condition: "Error", severity: "Error"},
+		{condition: "Warning", severity: "Warning"},
+		{reason: "BackOff", severity: "Error"},
+		{reason: "ForTesting", severity: "Info"},
+	}
+	for _, item := range table {
+		event := &api.Event{Condition: item.condition, Reason: item.reason}
+		_, field, err := rest.getAttrs(event)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if field["severity"] != item.severity {
+			t.Errorf("condition=%q reason=%q: expected severity %q, got %q", item.condition, item.reason, item.severity, field["severity"])
+		}
+	}
+}
+
 func TestRESTUpdate(t *testing.T) {
 	_, rest := NewTestREST()
 	eventA := testEvent("foo")
@@ -187,8 +287,9 @@ func TestRESTUpdate(t *testing.T) {
 }
 
 func TestRESTList(t *testing.T) {
-	reg, rest := NewTestREST()
+	_, rest := NewTestREST()
 	eventA := &api.Event{
+		ObjectMeta: api.ObjectMeta{Name: "a"},
 		InvolvedObject: api.ObjectReference{
 			Kind:            "Pod",
 			Name:            "foo",
@@ -201,6 +302,7 @@ func TestRESTList(t *testing.T) {
 		Reason:    "ForTesting",
 	}
 	eventB := &api.Event{
+		ObjectMeta: api.ObjectMeta{Name: "b"},
 		InvolvedObject: api.ObjectReference{
 			Kind:            "Pod",
 			Name:            "bar",
@@ -213,6 +315,7 @@ func TestRESTList(t *testing.T) {
 		Reason:    "ForTesting",
 	}
 	eventC := &api.Event{
+		ObjectMeta: api.ObjectMeta{Name: "c"},
 		InvolvedObject: api.ObjectReference{
 			Kind:            "Pod",
 			Name:            "baz",
@@ -224,21 +327,63 @@ func TestRESTList(t *testing.T) {
 		Condition: "Untested",
 		Reason:    "ForTesting",
 	}
-	reg.ObjectList = &api.EventList{
-		Items: []api.Event{*eventA, *eventB, *eventC},
+	for _, e := range []*api.Event{eventA, eventB, eventC} {
+		c, err := rest.Create(api.NewContext(), e)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		<-c
 	}
+
 	got, err := rest.List(api.NewContext(), labels.Everything(), labels.Set{"status": "Tested"}.AsSelector())
 	if err != nil {
 		t.Fatalf("Unexpected error %v", err)
 	}
-	expect := &api.EventList{
-		Items: []api.Event{*eventA, *eventB},
-	}
-	if e, a := expect, got; !reflect.DeepEqual(e, a) {
+	gotList := got.(*api.EventList)
+	if e, a := []api.Event{*eventA, *eventB}, gotList.Items; !reflect.DeepEqual(e, a) {
 		t.Errorf("diff: %s", util.ObjectDiff(e, a))
 	}
 }
 
+func TestRESTListTimeWindow(t *testing.T) {
+	_, rest := NewTestREST()
+	now := util.Now()
+	old := util.NewTime(now.Add(-2 * time.Hour))
+
+	oldEvent := &api.Event{ObjectMeta: api.ObjectMeta{Name: "old"}, LastTimestamp: old}
+	newEvent := &api.Event{ObjectMeta: api.ObjectMeta{Name: "new"}, LastTimestamp: now}
+	for _, e := range []*api.Event{oldEvent, newEvent} {
+		c, err := rest.Create(api.NewContext(), e)
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		<-c
+	}
+
+	cutoff := util.NewTime(now.Add(-time.Hour))
+	got, err := rest.List(api.NewContext(), labels.Everything(), labels.Everything(), TimeComparison{Field: "lastTimestamp", Op: ">=", Value: cutoff})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	gotList := got.(*api.EventList)
+	if len(gotList.Items) != 1 || gotList.Items[0].Name != "new" {
+		t.Errorf("expected only the new event, got: %#v", gotList)
+	}
+}
+
+func TestParseFieldSelector(t *testing.T) {
+	equality, comparisons, err := ParseFieldSelector("reason=ForTesting,lastTimestamp>=2015-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if equality != "reason=ForTesting" {
+		t.Errorf("expected equality clauses to be preserved, got %q", equality)
+	}
+	if len(comparisons) != 1 || comparisons[0].Field != "lastTimestamp" || comparisons[0].Op != ">=" {
+		t.Errorf("expected a single lastTimestamp>= comparison, got %#v", comparisons)
+	}
+}
+
 func TestRESTWatch(t *testing.T) {
 	eventA := &api.Event{
 		InvolvedObject: api.ObjectReference{
@@ -252,16 +397,16 @@ func TestRESTWatch(t *testing.T) {
 		Condition: "Tested",
 		Reason:    "ForTesting",
 	}
-	reg, rest := NewTestREST()
+	_, rest := NewTestREST()
 	wi, err := rest.Watch(api.NewContext(), labels.Everything(), labels.Everything(), "0")
 	if err != nil {
 		t.Fatalf("Unexpected error %v", err)
 	}
 	go func() {
-		reg.Broadcaster.Action(watch.Added, eventA)
+		rest.Create(api.NewContext(), eventA)
 	}()
 	got := <-wi.ResultChan()
 	if e, a := eventA, got.Object; !reflect.DeepEqual(e, a) {
-		t.Errorf("diff: %s", util.ObjectDiff(e, a))
+		t.Errorf("diff: %s", diff.ObjectReflectDiff(e, a))
 	}
 }
@@ -0,0 +1,58 @@
+// Package oauth implements a token authenticator backed by OAuth AccessTokens.
+package oauth
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	oauthcache "github.com/openshift/origin/pkg/oauth/cache"
+)
+
+// AccessTokenGetter retrieves a single AccessToken directly from storage. It is
+// consulted only on a cache miss.
+type AccessTokenGetter interface {
+	Get(name string) (*api.AccessToken, error)
+}
+
+// Authenticator validates bearer tokens against a cached view of AccessTokens,
+// falling back to a live lookup only when the token isn't found in cache - e.g.
+// because the informer hasn't caught up with a very recently created token yet.
+type Authenticator struct {
+	cache  *oauthcache.ExpiringIndexer
+	tokens AccessTokenGetter
+}
+
+// NewAuthenticator returns an Authenticator that checks cache before falling
+// back to tokens.Get.
+func NewAuthenticator(cache *oauthcache.ExpiringIndexer, tokens AccessTokenGetter) *Authenticator {
+	return &Authenticator{cache: cache, tokens: tokens}
+}
+
+// AuthenticateToken returns the user described by the given bearer token value,
+// or ok=false if the token does not exist or has expired.
+func (a *Authenticator) AuthenticateToken(value string) (user.Info, bool, error) {
+	token, exists, err := a.cache.GetByKey(value)
+	if err != nil {
+		return nil, false, err
+	}
+	if !exists {
+		token, err = a.tokens.Get(value)
+		if err != nil {
+			return nil, false, nil
+		}
+		if isExpired(token) {
+			return nil, false, nil
+		}
+	}
+
+	return &user.DefaultInfo{Name: token.UserName, UID: token.UserUID}, true, nil
+}
+
+func isExpired(token *api.AccessToken) bool {
+	if token.ExpiresIn == 0 {
+		return false
+	}
+	return token.CreationTimestamp.Add(time.Duration(token.ExpiresIn) * time.Second).Before(time.Now())
+}
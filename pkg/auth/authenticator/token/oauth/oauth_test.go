@@ -0,0 +1,117 @@
+package oauth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/auth/user"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	oauthcache "github.com/openshift/origin/pkg/oauth/cache"
+)
+
+// fakeAccessTokenGetter is a test double implementing AccessTokenGetter that
+// records whether it was consulted, so tests can assert a cache hit never
+// reaches the live lookup.
+type fakeAccessTokenGetter struct {
+	token *api.AccessToken
+	err   error
+	calls int
+}
+
+func (f *fakeAccessTokenGetter) Get(name string) (*api.AccessToken, error) {
+	f.calls++
+	return f.token, f.err
+}
+
+func tokenKeyFunc(obj interface{}) (string, error) {
+	return obj.(*api.AccessToken).Name, nil
+}
+
+func newTestAuthenticator(tokens AccessTokenGetter, cached ...*api.AccessToken) *Authenticator {
+	indexer := cache.NewIndexer(tokenKeyFunc, cache.Indexers{})
+	for _, token := range cached {
+		indexer.Add(token)
+	}
+	return NewAuthenticator(oauthcache.NewExpiringIndexer(indexer), tokens)
+}
+
+func TestAuthenticateTokenCacheHitSkipsLiveGet(t *testing.T) {
+	getter := &fakeAccessTokenGetter{err: errors.New("the live path should not be consulted on a cache hit")}
+	auth := newTestAuthenticator(getter, &api.AccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "cached-token"},
+		UserName:   "bob",
+		UserUID:    "123",
+	})
+
+	info, ok, err := auth.AuthenticateToken("cached-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected a cached token to authenticate")
+	}
+	if name, uid := info.(*user.DefaultInfo).Name, info.(*user.DefaultInfo).UID; name != "bob" || uid != "123" {
+		t.Errorf("unexpected user info: %#v", info)
+	}
+	if getter.calls != 0 {
+		t.Errorf("expected the live AccessTokenGetter not to be consulted, got %d calls", getter.calls)
+	}
+}
+
+func TestAuthenticateTokenCacheMissFallsBackToLiveGet(t *testing.T) {
+	getter := &fakeAccessTokenGetter{token: &api.AccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "live-token"},
+		UserName:   "alice",
+		UserUID:    "456",
+	}}
+	auth := newTestAuthenticator(getter)
+
+	info, ok, err := auth.AuthenticateToken("live-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected a live-fetched token to authenticate")
+	}
+	if name, uid := info.(*user.DefaultInfo).Name, info.(*user.DefaultInfo).UID; name != "alice" || uid != "456" {
+		t.Errorf("unexpected user info: %#v", info)
+	}
+	if getter.calls != 1 {
+		t.Errorf("expected the live AccessTokenGetter to be consulted once, got %d calls", getter.calls)
+	}
+}
+
+func TestAuthenticateTokenCacheMissExpiredLiveTokenRejected(t *testing.T) {
+	getter := &fakeAccessTokenGetter{token: &api.AccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "expired-token", CreationTimestamp: util.NewTime(time.Now().Add(-2 * time.Hour))},
+		ExpiresIn:  3600,
+		UserName:   "carol",
+	}}
+	auth := newTestAuthenticator(getter)
+
+	_, ok, err := auth.AuthenticateToken("expired-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Errorf("expected an expired token fetched via the live path to be rejected")
+	}
+}
+
+func TestAuthenticateTokenCacheMissGetterErrorRejectsWithoutError(t *testing.T) {
+	getter := &fakeAccessTokenGetter{err: errors.New("not found")}
+	auth := newTestAuthenticator(getter)
+
+	_, ok, err := auth.AuthenticateToken("missing-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Errorf("expected a lookup error to result in a failed authentication, not ok=true")
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// OAuthAccessTokensInterface has methods to work with OAuthAccessToken resources
+type OAuthAccessTokensInterface interface {
+	OAuthAccessTokens() OAuthAccessTokenInterface
+}
+
+// OAuthAccessTokenInterface exposes methods on OAuthAccessToken resources.
+type OAuthAccessTokenInterface interface {
+	Get(name string) (*oauthapi.AccessToken, error)
+	List(label, field labels.Selector) (*oauthapi.AccessTokenList, error)
+	Watch(label, field labels.Selector, resourceVersion string) (watch.Interface, error)
+	Delete(name string) error
+}
+
+// oauthAccessTokens implements OAuthAccessTokenInterface
+type oauthAccessTokens struct {
+	r *Client
+}
+
+// newOAuthAccessTokens returns an oauthAccessTokens
+func newOAuthAccessTokens(c *Client) *oauthAccessTokens {
+	return &oauthAccessTokens{r: c}
+}
+
+// Get returns information about a particular access token or an error
+func (c *oauthAccessTokens) Get(name string) (*oauthapi.AccessToken, error) {
+	result := &oauthapi.AccessToken{}
+	err := c.r.Get().Path("accessTokens").Path(name).Do().Into(result)
+	return result, err
+}
+
+// List returns a list of access tokens that match the label and field selectors
+func (c *oauthAccessTokens) List(label, field labels.Selector) (*oauthapi.AccessTokenList, error) {
+	result := &oauthapi.AccessTokenList{}
+	err := c.r.Get().Path("accessTokens").SelectorParam("labels", label).SelectorParam("fields", field).Do().Into(result)
+	return result, err
+}
+
+// Watch returns a watch.Interface that watches the requested access tokens
+func (c *oauthAccessTokens) Watch(label, field labels.Selector, resourceVersion string) (watch.Interface, error) {
+	return c.r.Get().Path("watch").Path("accessTokens").
+		Param("resourceVersion", resourceVersion).
+		SelectorParam("labels", label).
+		SelectorParam("fields", field).
+		Watch()
+}
+
+// Delete removes the named access token
+func (c *oauthAccessTokens) Delete(name string) error {
+	return c.r.Delete().Path("accessTokens").Path(name).Do().Error()
+}
@@ -0,0 +1,17 @@
+// Package latest tracks the newest externally-versioned representation of the
+// oauth API and exposes a Codec for it, mirroring the pattern used by the
+// other origin API groups.
+package latest
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+
+	_ "github.com/openshift/origin/pkg/oauth/api/v1beta1"
+)
+
+// Version is the string representing the current external default version.
+const Version = "v1beta1"
+
+// Codec is the codec for the current version.
+var Codec = runtime.CodecFor(kapi.Scheme, Version)
@@ -0,0 +1,27 @@
+package api
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func init() {
+	kapi.Scheme.AddKnownTypes("",
+		&AccessToken{},
+		&AccessTokenList{},
+		&AuthorizeToken{},
+		&AuthorizeTokenList{},
+		&Client{},
+		&ClientList{},
+		&ClientAuthorization{},
+		&ClientAuthorizationList{},
+	)
+}
+
+func (*AccessToken) IsAnAPIObject()             {}
+func (*AccessTokenList) IsAnAPIObject()         {}
+func (*AuthorizeToken) IsAnAPIObject()          {}
+func (*AuthorizeTokenList) IsAnAPIObject()      {}
+func (*Client) IsAnAPIObject()                  {}
+func (*ClientList) IsAnAPIObject()              {}
+func (*ClientAuthorization) IsAnAPIObject()     {}
+func (*ClientAuthorizationList) IsAnAPIObject() {}
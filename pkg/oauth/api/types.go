@@ -0,0 +1,180 @@
+package api
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+)
+
+// AccessToken is a long lived token that grants access to a user's resources
+type AccessToken struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// ClientName references the client that created this token.
+	ClientName string
+
+	// ExpiresIn is the seconds from CreationTime before this token expires.
+	ExpiresIn int64
+
+	// Scopes is an array of the requested scopes.
+	Scopes []string
+
+	// RedirectURI is the redirection associated with the token.
+	RedirectURI string
+
+	// UserName is the user name associated with this token
+	UserName string
+
+	// UserUID is the unique UID associated with this token
+	UserUID string
+
+	// AuthorizeToken contains the token that authorized this token
+	AuthorizeToken string
+
+	// RefreshToken is the value by which this token can be renewed. Can be blank.
+	RefreshToken string
+
+	// IDToken is the compact-serialized OpenID Connect ID Token issued alongside this
+	// access token, present only when the authorization request included the "openid" scope.
+	IDToken string
+}
+
+// AuthorizeToken is a short lived token that authorizes a client to request an AccessToken
+type AuthorizeToken struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// ClientName references the client that created this token.
+	ClientName string
+
+	// ExpiresIn is the seconds from CreationTime before this token expires.
+	ExpiresIn int64
+
+	// Scopes is an array of the requested scopes.
+	Scopes []string
+
+	// RedirectURI is the redirection associated with the token.
+	RedirectURI string
+
+	// State data from request
+	State string
+
+	// UserName is the user name associated with this token
+	UserName string
+
+	// UserUID is the unique UID associated with this token. UserUID and UserName must both match
+	// for this token to be valid.
+	UserUID string
+
+	// Nonce carries the value passed by the client in the authorization request, echoed back in
+	// the resulting ID Token's "nonce" claim per the OpenID Connect core spec.
+	Nonce string
+}
+
+// Client is a client that is allowed to request authorizations from users
+type Client struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// Secret is the unique secret associated with a client
+	Secret string
+
+	// RespondWithChallenges indicates whether the client wants authentication needed responses made in the form of challenges instead of redirects
+	RespondWithChallenges bool
+
+	// RedirectURIs is the valid redirection URIs associated with a client
+	RedirectURIs []string
+
+	// Finalizers is an opaque list of values that must be empty to permanently remove object from storage
+	Finalizers []string
+
+	// DeletionTimestamp, if non-nil, is the time at which this client was requested to be deleted. The
+	// client is retained in storage until its Finalizers list drains, at which point it is removed.
+	DeletionTimestamp *util.Time
+
+	// AllowedGrantTypes restricts which grant types this client may use. A client must include
+	// "implicit" or "authorization_code" here and request the "openid" scope to receive an IDToken.
+	AllowedGrantTypes []string
+
+	// IDTokenSignedResponseAlg is the JWS alg header value used to sign this client's ID Tokens.
+	// Only "RS256" is currently supported; defaults to "RS256" when empty.
+	IDTokenSignedResponseAlg string
+}
+
+// ClientAuthorization indicates how a user authorized a client
+type ClientAuthorization struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// ClientName references the client that created this authorization
+	ClientName string
+
+	// UserName is the user name that authorized this client
+	UserName string
+
+	// UserUID is the unique UID associated with this authorization. UserUID and UserName
+	// must both match for this authorization to be valid.
+	UserUID string
+
+	// Scopes is an array of the granted scopes.
+	Scopes []string
+}
+
+// AccessTokenList is a collection of AccessTokens
+type AccessTokenList struct {
+	kapi.TypeMeta
+	kapi.ListMeta
+	Items []AccessToken
+}
+
+// AuthorizeTokenList is a collection of AuthorizeTokens
+type AuthorizeTokenList struct {
+	kapi.TypeMeta
+	kapi.ListMeta
+	Items []AuthorizeToken
+}
+
+// ClientList is a collection of Clients
+type ClientList struct {
+	kapi.TypeMeta
+	kapi.ListMeta
+	Items []Client
+}
+
+// ClientAuthorizationList is a collection of ClientAuthorizations
+type ClientAuthorizationList struct {
+	kapi.TypeMeta
+	kapi.ListMeta
+	Items []ClientAuthorization
+}
+
+const (
+	// FinalizerOAuthTokens is placed on Clients and Users by the token garbage
+	// collector. It is removed once all AccessToken, AuthorizeToken, and
+	// ClientAuthorization objects referencing the owner have been deleted.
+	FinalizerOAuthTokens = "openshift.io/oauth-tokens"
+)
+
+// DeletionPropagation decides if a deletion will propagate to the tokens and
+// authorizations that reference the deleted Client or User, and how the garbage
+// collector will react.
+type DeletionPropagation string
+
+const (
+	// DeletePropagationOrphan means the dependent tokens/authorizations are left
+	// behind once the owner's finalizer is removed; nothing enumerates or removes them.
+	DeletePropagationOrphan DeletionPropagation = "Orphan"
+	// DeletePropagationForeground means the owner is not removed from storage until
+	// the garbage collector has deleted every dependent token/authorization and
+	// drained the finalizer.
+	DeletePropagationForeground DeletionPropagation = "Foreground"
+)
+
+// DeleteOptions may be provided when deleting an object to customize the behavior of the delete.
+type DeleteOptions struct {
+	kapi.TypeMeta
+
+	// PropagationPolicy determines whether and how dependent tokens/authorizations are
+	// garbage collected. Defaults to DeletePropagationForeground.
+	PropagationPolicy *DeletionPropagation
+}
@@ -0,0 +1,105 @@
+package v1beta1_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util/diff"
+	"github.com/google/gofuzz"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/api/latest"
+)
+
+// all is every type registered for this API group's internal version. If a
+// field is renamed or removed in the internal API but the matching v1beta1
+// conversion is forgotten, fuzzing these and round-tripping them out to
+// v1beta1 and back will surface it as a silent data drop.
+var all = []runtime.Object{
+	&api.AccessToken{},
+	&api.AccessTokenList{},
+	&api.AuthorizeToken{},
+	&api.AuthorizeTokenList{},
+	&api.Client{},
+	&api.ClientList{},
+	&api.ClientAuthorization{},
+	&api.ClientAuthorizationList{},
+}
+
+// roundTrip encodes obj (an internal type) through the v1beta1 codec, decodes
+// the result back into a fresh internal instance, DecodeInto's it into a
+// second freshly-allocated internal instance, and re-encodes a DeepCopy of
+// obj, failing the test if any of those diverge from the original or from
+// each other.
+func roundTrip(t *testing.T, obj runtime.Object, seed int64) {
+	fuzz.New().RandSource(rand.NewSource(seed)).NilChance(0).NumElements(1, 3).Fuzz(obj)
+	// TypeMeta is stamped by the codec itself on encode/decode, not part of the
+	// data conversions this test is checking for, so it isn't fuzzed.
+	resetTypeMeta(obj)
+
+	data, err := latest.Codec.Encode(obj)
+	if err != nil {
+		t.Errorf("%T: unable to encode: %v", obj, err)
+		return
+	}
+
+	decoded, err := latest.Codec.Decode(data)
+	if err != nil {
+		t.Errorf("%T: unable to decode: %v", obj, err)
+		return
+	}
+	resetTypeMeta(decoded)
+	if !kapi.Semantic.DeepEqual(obj, decoded) {
+		t.Errorf("%T: decode diff: %v", obj, diff.ObjectReflectDiff(obj, decoded))
+	}
+
+	into := reflect.New(reflect.TypeOf(obj).Elem()).Interface().(runtime.Object)
+	if err := latest.Codec.DecodeInto(data, into); err != nil {
+		t.Errorf("%T: unable to DecodeInto: %v", obj, err)
+		return
+	}
+	resetTypeMeta(into)
+	if !kapi.Semantic.DeepEqual(obj, into) {
+		t.Errorf("%T: DecodeInto diff: %v", obj, diff.ObjectReflectDiff(obj, into))
+	}
+
+	before, err := latest.Codec.Encode(obj)
+	if err != nil {
+		t.Errorf("%T: unable to re-encode before copy: %v", obj, err)
+		return
+	}
+	copiedObj, err := kapi.Scheme.Copy(obj)
+	if err != nil {
+		t.Errorf("%T: unable to deep copy: %v", obj, err)
+		return
+	}
+	copied := copiedObj.(runtime.Object)
+	copiedData, err := latest.Codec.Encode(copied)
+	if err != nil {
+		t.Errorf("%T: unable to encode copy: %v", obj, err)
+		return
+	}
+	if string(before) != string(copiedData) {
+		t.Errorf("%T: encoding a deep copy produced different bytes than encoding the original", obj)
+	}
+}
+
+// resetTypeMeta zeroes the embedded kapi.TypeMeta on obj. The codec stamps its
+// own Kind/APIVersion on encode and decode, so a fuzzed value here would
+// produce a spurious diff unrelated to the field round-tripping this test
+// checks for.
+func resetTypeMeta(obj runtime.Object) {
+	field := reflect.ValueOf(obj).Elem().FieldByName("TypeMeta")
+	if field.IsValid() {
+		field.Set(reflect.Zero(field.Type()))
+	}
+}
+
+func TestRoundTripTypes(t *testing.T) {
+	for i, obj := range all {
+		roundTrip(t, obj, int64(i)+1)
+	}
+}
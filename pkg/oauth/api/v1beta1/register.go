@@ -0,0 +1,27 @@
+package v1beta1
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+)
+
+func init() {
+	kapi.Scheme.AddKnownTypes("v1beta1",
+		&AccessToken{},
+		&AccessTokenList{},
+		&AuthorizeToken{},
+		&AuthorizeTokenList{},
+		&Client{},
+		&ClientList{},
+		&ClientAuthorization{},
+		&ClientAuthorizationList{},
+	)
+}
+
+func (*AccessToken) IsAnAPIObject()             {}
+func (*AccessTokenList) IsAnAPIObject()         {}
+func (*AuthorizeToken) IsAnAPIObject()          {}
+func (*AuthorizeTokenList) IsAnAPIObject()      {}
+func (*Client) IsAnAPIObject()                  {}
+func (*ClientList) IsAnAPIObject()              {}
+func (*ClientAuthorization) IsAnAPIObject()     {}
+func (*ClientAuthorizationList) IsAnAPIObject() {}
@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api/v1beta3"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
 )
 
 type AccessToken struct {
@@ -31,6 +32,10 @@ type AccessToken struct {
 
 	// RefreshToken is the value by which this token can be renewed. Can be blank.
 	RefreshToken string `json:"refreshToken,omitempty" yaml:"refreshToken,omitempty"`
+
+	// IDToken is the compact-serialized OpenID Connect ID Token issued alongside this
+	// access token, present only when the authorization request included the "openid" scope.
+	IDToken string `json:"idToken,omitempty" yaml:"idToken,omitempty"`
 }
 
 type AuthorizeToken struct {
@@ -58,6 +63,10 @@ type AuthorizeToken struct {
 	// UserUID is the unique UID associated with this token. UserUID and UserName must both match
 	// for this token to be valid.
 	UserUID string `json:"userUID,omitempty" yaml:"userUID,omitempty"`
+
+	// Nonce carries the value passed by the client in the authorization request, echoed back in
+	// the resulting ID Token's "nonce" claim per the OpenID Connect core spec.
+	Nonce string `json:"nonce,omitempty" yaml:"nonce,omitempty"`
 }
 
 type Client struct {
@@ -72,6 +81,23 @@ type Client struct {
 
 	// RedirectURIs is the valid redirection URIs associated with a client
 	RedirectURIs []string `json:"redirectURIs,omitempty" yaml:"redirectURIs,omitempty"`
+
+	// Finalizers is an opaque list of values that must be empty to permanently remove object from storage.
+	// A client with a non-empty Finalizers list cannot be removed until the list is drained by the
+	// controllers that added an entry to it (e.g. the token garbage collector).
+	Finalizers []string `json:"finalizers,omitempty" yaml:"finalizers,omitempty"`
+
+	// DeletionTimestamp, if non-nil, is the time at which this client was requested to be deleted.
+	// The client is retained in storage until its Finalizers list drains, at which point it is removed.
+	DeletionTimestamp *util.Time `json:"deletionTimestamp,omitempty" yaml:"deletionTimestamp,omitempty"`
+
+	// AllowedGrantTypes restricts which grant types this client may use. A client must include
+	// "implicit" or "authorization_code" here and request the "openid" scope to receive an IDToken.
+	AllowedGrantTypes []string `json:"allowedGrantTypes,omitempty" yaml:"allowedGrantTypes,omitempty"`
+
+	// IDTokenSignedResponseAlg is the JWS alg header value used to sign this client's ID Tokens.
+	// Only "RS256" is currently supported; defaults to "RS256" when empty.
+	IDTokenSignedResponseAlg string `json:"idTokenSignedResponseAlg,omitempty" yaml:"idTokenSignedResponseAlg,omitempty"`
 }
 
 type ClientAuthorization struct {
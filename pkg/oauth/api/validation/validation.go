@@ -0,0 +1,39 @@
+package validation
+
+import (
+	kvalidation "github.com/GoogleCloudPlatform/kubernetes/pkg/util/fielderrors"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// ValidateClient validates a new client
+func ValidateClient(client *api.Client) kvalidation.ValidationErrorList {
+	allErrs := kvalidation.ValidationErrorList{}
+	if len(client.Name) == 0 {
+		allErrs = append(allErrs, kvalidation.NewFieldRequired("name"))
+	}
+	return allErrs
+}
+
+// ValidateClientUpdate validates an update to a client, ensuring the update does not
+// remove a RedirectURI that is still referenced by an outstanding AuthorizeToken.
+func ValidateClientUpdate(client *api.Client, older *api.Client, outstandingRedirectURIs map[string]bool) kvalidation.ValidationErrorList {
+	allErrs := kvalidation.ValidationErrorList{}
+	allErrs = append(allErrs, ValidateClient(client)...)
+
+	if client.Name != older.Name {
+		allErrs = append(allErrs, kvalidation.NewFieldInvalid("name", client.Name, "name is immutable"))
+	}
+
+	retained := map[string]bool{}
+	for _, uri := range client.RedirectURIs {
+		retained[uri] = true
+	}
+	for uri := range outstandingRedirectURIs {
+		if !retained[uri] {
+			allErrs = append(allErrs, kvalidation.NewFieldInvalid("redirectURIs", client.RedirectURIs, "redirectURI is still referenced by an outstanding AuthorizeToken and cannot be removed"))
+		}
+	}
+
+	return allErrs
+}
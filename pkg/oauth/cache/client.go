@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// OAuthClientsInterface is the subset of client.OAuthClientsInterface needed to
+// drive a Client informer; kept narrow so tests can supply a stub.
+type OAuthClientsInterface interface {
+	List(label, field labels.Selector) (*api.ClientList, error)
+	Watch(label, field labels.Selector, resourceVersion string) (watch.Interface, error)
+}
+
+func clientKeyFunc(obj interface{}) (string, error) {
+	return obj.(*api.Client).Name, nil
+}
+
+// NewClientInformer returns an Indexer mirroring every Client known to the server
+// plus a Reflector that keeps it in sync. Call Run on the returned Reflector to
+// start the watch loop.
+func NewClientInformer(c OAuthClientsInterface, resyncPeriod time.Duration) (cache.Indexer, *cache.Reflector) {
+	indexer := cache.NewIndexer(clientKeyFunc, cache.Indexers{})
+
+	lw := &cache.ListWatch{
+		ListFunc: func() (runtime.Object, error) {
+			return c.List(labels.Everything(), labels.Everything())
+		},
+		WatchFunc: func(resourceVersion string) (watch.Interface, error) {
+			return c.Watch(labels.Everything(), labels.Everything(), resourceVersion)
+		},
+	}
+	reflector := cache.NewReflector(lw, &api.Client{}, indexer, resyncPeriod)
+
+	return indexer, reflector
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// ExpiringIndexer wraps a cache.Indexer of AccessTokens and hides entries whose
+// CreationTimestamp + ExpiresIn has already passed, evicting them from the
+// underlying store as they're discovered instead of waiting for the next full
+// resync. This lets expired tokens fall out of the cache without a server round
+// trip, while still bounding how long a stale entry can linger.
+type ExpiringIndexer struct {
+	indexer cache.Indexer
+}
+
+// NewExpiringIndexer wraps indexer with expiration-aware lookups.
+func NewExpiringIndexer(indexer cache.Indexer) *ExpiringIndexer {
+	return &ExpiringIndexer{indexer: indexer}
+}
+
+// GetByKey returns the AccessToken named key, or exists=false if it is absent
+// or has expired.
+func (e *ExpiringIndexer) GetByKey(key string) (token *api.AccessToken, exists bool, err error) {
+	obj, exists, err := e.indexer.GetByKey(key)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+	token = obj.(*api.AccessToken)
+	if isExpired(token) {
+		e.indexer.Delete(token)
+		return nil, false, nil
+	}
+	return token, true, nil
+}
+
+// ByIndex returns every non-expired AccessToken whose indexName index contains
+// indexedValue, e.g. ByIndex(ByClientNameIndex, "my-client").
+func (e *ExpiringIndexer) ByIndex(indexName, indexedValue string) ([]*api.AccessToken, error) {
+	objs, err := e.indexer.ByIndex(indexName, indexedValue)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]*api.AccessToken, 0, len(objs))
+	for _, obj := range objs {
+		token := obj.(*api.AccessToken)
+		if isExpired(token) {
+			e.indexer.Delete(token)
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func isExpired(token *api.AccessToken) bool {
+	if token.ExpiresIn == 0 {
+		return false
+	}
+	expiry := token.CreationTimestamp.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return time.Now().After(expiry)
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/client/cache"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+func TestExpiringIndexerGetByKey(t *testing.T) {
+	indexer := cache.NewIndexer(accessTokenKeyFunc, accessTokenIndexers)
+	indexer.Add(&api.AccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "live", CreationTimestamp: util.Now()},
+		ExpiresIn:  3600,
+	})
+	indexer.Add(&api.AccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "expired", CreationTimestamp: util.NewTime(time.Now().Add(-2 * time.Hour))},
+		ExpiresIn:  3600,
+	})
+
+	expiring := NewExpiringIndexer(indexer)
+
+	if _, exists, err := expiring.GetByKey("live"); err != nil || !exists {
+		t.Errorf("expected live token to be found, exists=%v err=%v", exists, err)
+	}
+
+	if _, exists, err := expiring.GetByKey("expired"); err != nil || exists {
+		t.Errorf("expected expired token to be hidden, exists=%v err=%v", exists, err)
+	}
+	if _, stillThere, _ := indexer.GetByKey("expired"); stillThere {
+		t.Errorf("expected expired token to be evicted from the underlying indexer")
+	}
+}
+
+func TestExpiringIndexerByIndex(t *testing.T) {
+	indexer := cache.NewIndexer(accessTokenKeyFunc, accessTokenIndexers)
+	indexer.Add(&api.AccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "live", CreationTimestamp: util.Now()},
+		ClientName: "myclient",
+		ExpiresIn:  3600,
+	})
+	indexer.Add(&api.AccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "expired", CreationTimestamp: util.NewTime(time.Now().Add(-2 * time.Hour))},
+		ClientName: "myclient",
+		ExpiresIn:  3600,
+	})
+
+	expiring := NewExpiringIndexer(indexer)
+	tokens, err := expiring.ByIndex(ByClientNameIndex, "myclient")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Name != "live" {
+		t.Errorf("expected only the live token, got: %#v", tokens)
+	}
+}
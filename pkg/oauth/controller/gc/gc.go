@@ -0,0 +1,270 @@
+// Package gc implements cascading deletion of OAuth tokens and authorizations
+// that belong to a Client or User once that owner has been marked for deletion.
+package gc
+
+import (
+	"time"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/accesstoken"
+	"github.com/openshift/origin/pkg/oauth/registry/authorizetoken"
+	oauthclient "github.com/openshift/origin/pkg/oauth/registry/client"
+	"github.com/openshift/origin/pkg/oauth/registry/clientauthorization"
+)
+
+// DependentRegistry is implemented by the registries for AccessToken,
+// AuthorizeToken, and ClientAuthorization: resources that are owned by a
+// Client (via ClientName) or a User (via UserUID) and must be removed when
+// their owner is removed.
+type DependentRegistry interface {
+	// ListDependents returns the names of every object whose ClientName or UserUID
+	// field matches the given selector.
+	ListDependents(ctx kapi.Context, label, field labels.Selector) ([]string, error)
+	// DeleteDependent removes the named object.
+	DeleteDependent(ctx kapi.Context, name string) error
+}
+
+// Owner is the minimal view a cascade needs of a Client or User: the value
+// its dependents are selected by (a client name or a user UID), whether it
+// has been marked for deletion, and its current Finalizers.
+type Owner struct {
+	// SelectorValue is matched against dependents' clientName or userUID field.
+	SelectorValue     string
+	DeletionTimestamp *util.Time
+	Finalizers        []string
+}
+
+// OwnerRegistry lists the owners of one kind (Client or User) pending
+// reconciliation and drains FinalizerOAuthTokens from the named owner once
+// its dependents have been removed.
+type OwnerRegistry interface {
+	// ListOwners returns every owner of this kind, regardless of deletion state.
+	ListOwners(ctx kapi.Context) ([]Owner, error)
+	// RemoveFinalizer removes FinalizerOAuthTokens from the named owner's
+	// Finalizers list. It is a no-op if the finalizer is already absent.
+	RemoveFinalizer(ctx kapi.Context, selectorValue string) error
+}
+
+// ownerKind pairs an OwnerRegistry with the field selector key ("clientName" or
+// "userUID") dependents use to reference that kind of owner.
+type ownerKind struct {
+	selectorKey string
+	registry    OwnerRegistry
+}
+
+// TokenGCController watches for Clients and Users that have been marked for
+// deletion, deletes the AccessTokens, AuthorizeTokens, and
+// ClientAuthorizations that reference them, and then drains the owner's
+// FinalizerOAuthTokens entry so that it can be permanently removed from
+// storage.
+type TokenGCController struct {
+	owners               []ownerKind
+	accessTokens         DependentRegistry
+	authorizeTokens      DependentRegistry
+	clientAuthorizations DependentRegistry
+	resyncPeriod         time.Duration
+}
+
+// NewTokenGCController creates a new TokenGCController. users may be nil, in
+// which case only Client deletions are cascaded; supply a users OwnerRegistry
+// backed by the user API's registry to also cascade User deletions.
+func NewTokenGCController(clients oauthclient.Registry, users OwnerRegistry, accessTokens, authorizeTokens, clientAuthorizations DependentRegistry, resyncPeriod time.Duration) *TokenGCController {
+	owners := []ownerKind{
+		{selectorKey: "clientName", registry: clientOwnerRegistry{clients}},
+	}
+	if users != nil {
+		owners = append(owners, ownerKind{selectorKey: "userUID", registry: users})
+	}
+
+	return &TokenGCController{
+		owners:               owners,
+		accessTokens:         accessTokens,
+		authorizeTokens:      authorizeTokens,
+		clientAuthorizations: clientAuthorizations,
+		resyncPeriod:         resyncPeriod,
+	}
+}
+
+// NewTokenGCControllerFromRegistries is a convenience constructor that wraps the
+// concrete AccessToken, AuthorizeToken, and ClientAuthorization registries as
+// DependentRegistrys before delegating to NewTokenGCController.
+func NewTokenGCControllerFromRegistries(clients oauthclient.Registry, users OwnerRegistry, accessTokens accesstoken.Registry, authorizeTokens authorizetoken.Registry, clientAuthorizations clientauthorization.Registry, resyncPeriod time.Duration) *TokenGCController {
+	return NewTokenGCController(
+		clients,
+		users,
+		accessTokenDependents{accessTokens},
+		authorizeTokenDependents{authorizeTokens},
+		clientAuthorizationDependents{clientAuthorizations},
+		resyncPeriod,
+	)
+}
+
+// Run starts the controller's reconciliation loop and blocks until stopCh is closed.
+func (c *TokenGCController) Run(stopCh <-chan struct{}) {
+	go util.Until(c.reconcile, c.resyncPeriod, stopCh)
+	<-stopCh
+}
+
+// reconcile scans for Clients and Users pending deletion and cascades their dependents.
+func (c *TokenGCController) reconcile() {
+	ctx := kapi.NewContext()
+
+	for _, kind := range c.owners {
+		owners, err := kind.registry.ListOwners(ctx)
+		if err != nil {
+			glog.Errorf("token gc: unable to list %s owners: %v", kind.selectorKey, err)
+			continue
+		}
+
+		for _, owner := range owners {
+			if owner.DeletionTimestamp == nil {
+				continue
+			}
+			if err := c.reconcileOwner(ctx, kind, owner); err != nil {
+				glog.Errorf("token gc: unable to reconcile %s=%s: %v", kind.selectorKey, owner.SelectorValue, err)
+			}
+		}
+	}
+}
+
+// reconcileOwner deletes every AccessToken, AuthorizeToken, and
+// ClientAuthorization selected by kind.selectorKey=owner.SelectorValue, then
+// removes the owner's FinalizerOAuthTokens entry.
+func (c *TokenGCController) reconcileOwner(ctx kapi.Context, kind ownerKind, owner Owner) error {
+	if len(owner.Finalizers) == 0 {
+		// finalizer already drained
+		return nil
+	}
+
+	selector := labels.Set{kind.selectorKey: owner.SelectorValue}.AsSelector()
+
+	for _, dependents := range []DependentRegistry{c.accessTokens, c.authorizeTokens, c.clientAuthorizations} {
+		names, err := dependents.ListDependents(ctx, labels.Everything(), selector)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if err := dependents.DeleteDependent(ctx, name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return kind.registry.RemoveFinalizer(ctx, owner.SelectorValue)
+}
+
+// drainFinalizer returns finalizers with api.FinalizerOAuthTokens removed, and
+// whether anything was actually removed.
+func drainFinalizer(finalizers []string) ([]string, bool) {
+	drained := make([]string, 0, len(finalizers))
+	for _, f := range finalizers {
+		if f != api.FinalizerOAuthTokens {
+			drained = append(drained, f)
+		}
+	}
+	return drained, len(drained) != len(finalizers)
+}
+
+// clientOwnerRegistry adapts oauthclient.Registry to OwnerRegistry, treating
+// every Client as an owner selected by its Name.
+type clientOwnerRegistry struct {
+	registry oauthclient.Registry
+}
+
+func (r clientOwnerRegistry) ListOwners(ctx kapi.Context) ([]Owner, error) {
+	list, err := r.registry.ListClients(ctx, labels.Everything(), labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	owners := make([]Owner, 0, len(list.Items))
+	for i := range list.Items {
+		client := &list.Items[i]
+		owners = append(owners, Owner{
+			SelectorValue:     client.Name,
+			DeletionTimestamp: client.DeletionTimestamp,
+			Finalizers:        client.Finalizers,
+		})
+	}
+	return owners, nil
+}
+
+func (r clientOwnerRegistry) RemoveFinalizer(ctx kapi.Context, name string) error {
+	client, err := r.registry.GetClient(ctx, name)
+	if err != nil {
+		return err
+	}
+	drained, changed := drainFinalizer(client.Finalizers)
+	if !changed {
+		return nil
+	}
+	client.Finalizers = drained
+	return r.registry.UpdateClient(ctx, client)
+}
+
+// accessTokenDependents adapts accesstoken.Registry to DependentRegistry.
+type accessTokenDependents struct {
+	registry accesstoken.Registry
+}
+
+func (d accessTokenDependents) ListDependents(ctx kapi.Context, label, field labels.Selector) ([]string, error) {
+	list, err := d.registry.ListAccessTokens(ctx, label, field)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, token := range list.Items {
+		names = append(names, token.Name)
+	}
+	return names, nil
+}
+
+func (d accessTokenDependents) DeleteDependent(ctx kapi.Context, name string) error {
+	return d.registry.DeleteAccessToken(ctx, name)
+}
+
+// authorizeTokenDependents adapts authorizetoken.Registry to DependentRegistry.
+type authorizeTokenDependents struct {
+	registry authorizetoken.Registry
+}
+
+func (d authorizeTokenDependents) ListDependents(ctx kapi.Context, label, field labels.Selector) ([]string, error) {
+	list, err := d.registry.ListAuthorizeTokens(ctx, label, field)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, token := range list.Items {
+		names = append(names, token.Name)
+	}
+	return names, nil
+}
+
+func (d authorizeTokenDependents) DeleteDependent(ctx kapi.Context, name string) error {
+	return d.registry.DeleteAuthorizeToken(ctx, name)
+}
+
+// clientAuthorizationDependents adapts clientauthorization.Registry to DependentRegistry.
+type clientAuthorizationDependents struct {
+	registry clientauthorization.Registry
+}
+
+func (d clientAuthorizationDependents) ListDependents(ctx kapi.Context, label, field labels.Selector) ([]string, error) {
+	list, err := d.registry.ListClientAuthorizations(ctx, label, field)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, auth := range list.Items {
+		names = append(names, auth.Name)
+	}
+	return names, nil
+}
+
+func (d clientAuthorizationDependents) DeleteDependent(ctx kapi.Context, name string) error {
+	return d.registry.DeleteClientAuthorization(ctx, name)
+}
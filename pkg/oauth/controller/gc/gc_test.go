@@ -0,0 +1,135 @@
+package gc
+
+import (
+	"reflect"
+	"testing"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	oauthtest "github.com/openshift/origin/pkg/oauth/registry/test"
+)
+
+// fakeOwnerRegistry is a minimal in-memory OwnerRegistry, used to exercise the
+// User cascade path even though this tree has no user API registry to adapt.
+type fakeOwnerRegistry struct {
+	owners map[string]Owner
+}
+
+func (r *fakeOwnerRegistry) ListOwners(ctx kapi.Context) ([]Owner, error) {
+	owners := make([]Owner, 0, len(r.owners))
+	for _, owner := range r.owners {
+		owners = append(owners, owner)
+	}
+	return owners, nil
+}
+
+func (r *fakeOwnerRegistry) RemoveFinalizer(ctx kapi.Context, selectorValue string) error {
+	owner := r.owners[selectorValue]
+	drained, _ := drainFinalizer(owner.Finalizers)
+	owner.Finalizers = drained
+	r.owners[selectorValue] = owner
+	return nil
+}
+
+func TestReconcileClientCascadeDeletesDependentsAndDrainsFinalizer(t *testing.T) {
+	now := util.Now()
+	clients := &oauthtest.ClientRegistry{
+		Clients: &api.ClientList{Items: []api.Client{
+			{
+				ObjectMeta:        kapi.ObjectMeta{Name: "myclient"},
+				Finalizers:        []string{api.FinalizerOAuthTokens},
+				DeletionTimestamp: &now,
+			},
+		}},
+		Client: &api.Client{
+			ObjectMeta: kapi.ObjectMeta{Name: "myclient"},
+			Finalizers: []string{api.FinalizerOAuthTokens},
+		},
+	}
+	accessTokens := &oauthtest.AccessTokenRegistry{
+		Tokens: &api.AccessTokenList{Items: []api.AccessToken{{ObjectMeta: kapi.ObjectMeta{Name: "access1"}}}},
+	}
+	authorizeTokens := &oauthtest.AuthorizeTokenRegistry{
+		Tokens: &api.AuthorizeTokenList{Items: []api.AuthorizeToken{{ObjectMeta: kapi.ObjectMeta{Name: "authorize1"}}}},
+	}
+	clientAuthorizations := &oauthtest.ClientAuthorizationRegistry{
+		Auths: &api.ClientAuthorizationList{Items: []api.ClientAuthorization{{ObjectMeta: kapi.ObjectMeta{Name: "auth1"}}}},
+	}
+
+	controller := NewTokenGCControllerFromRegistries(clients, nil, accessTokens, authorizeTokens, clientAuthorizations, 0)
+	controller.reconcile()
+
+	if accessTokens.DeletedTokenName != "access1" {
+		t.Errorf("expected access token to be deleted, got: %q", accessTokens.DeletedTokenName)
+	}
+	if authorizeTokens.DeletedTokenName != "authorize1" {
+		t.Errorf("expected authorize token to be deleted, got: %q", authorizeTokens.DeletedTokenName)
+	}
+	if clientAuthorizations.DeletedAuthName != "auth1" {
+		t.Errorf("expected client authorization to be deleted, got: %q", clientAuthorizations.DeletedAuthName)
+	}
+	if clients.UpdatedClient == nil || len(clients.UpdatedClient.Finalizers) != 0 {
+		t.Errorf("expected the client's finalizer to be drained, got: %#v", clients.UpdatedClient)
+	}
+}
+
+func TestReconcileSkipsOwnersNotPendingDeletion(t *testing.T) {
+	clients := &oauthtest.ClientRegistry{
+		Clients: &api.ClientList{Items: []api.Client{
+			{ObjectMeta: kapi.ObjectMeta{Name: "myclient"}, Finalizers: []string{api.FinalizerOAuthTokens}},
+		}},
+	}
+	accessTokens := &oauthtest.AccessTokenRegistry{}
+	authorizeTokens := &oauthtest.AuthorizeTokenRegistry{}
+	clientAuthorizations := &oauthtest.ClientAuthorizationRegistry{}
+
+	controller := NewTokenGCControllerFromRegistries(clients, nil, accessTokens, authorizeTokens, clientAuthorizations, 0)
+	controller.reconcile()
+
+	if clients.UpdatedClient != nil {
+		t.Errorf("expected no reconciliation for a client without a DeletionTimestamp, got: %#v", clients.UpdatedClient)
+	}
+}
+
+func TestReconcileUserCascadeUsesUserUIDSelector(t *testing.T) {
+	now := util.Now()
+	clients := &oauthtest.ClientRegistry{Clients: &api.ClientList{}}
+	users := &fakeOwnerRegistry{owners: map[string]Owner{
+		"user-uid-1": {SelectorValue: "user-uid-1", Finalizers: []string{api.FinalizerOAuthTokens}, DeletionTimestamp: &now},
+	}}
+	accessTokens := &oauthtest.AccessTokenRegistry{
+		Tokens: &api.AccessTokenList{Items: []api.AccessToken{{ObjectMeta: kapi.ObjectMeta{Name: "access1"}}}},
+	}
+	authorizeTokens := &oauthtest.AuthorizeTokenRegistry{Tokens: &api.AuthorizeTokenList{}}
+	clientAuthorizations := &oauthtest.ClientAuthorizationRegistry{Auths: &api.ClientAuthorizationList{}}
+
+	controller := NewTokenGCControllerFromRegistries(clients, users, accessTokens, authorizeTokens, clientAuthorizations, 0)
+	controller.reconcile()
+
+	if accessTokens.DeletedTokenName != "access1" {
+		t.Errorf("expected the user's access token to be deleted, got: %q", accessTokens.DeletedTokenName)
+	}
+	if users.owners["user-uid-1"].Finalizers != nil && len(users.owners["user-uid-1"].Finalizers) != 0 {
+		t.Errorf("expected the user's finalizer to be drained, got: %#v", users.owners["user-uid-1"])
+	}
+}
+
+func TestDrainFinalizer(t *testing.T) {
+	drained, changed := drainFinalizer([]string{api.FinalizerOAuthTokens, "other"})
+	if !changed {
+		t.Errorf("expected drainFinalizer to report a change")
+	}
+	if e, a := []string{"other"}, drained; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %#v, got %#v", e, a)
+	}
+
+	drained, changed = drainFinalizer([]string{"other"})
+	if changed {
+		t.Errorf("expected drainFinalizer to report no change when the finalizer is absent")
+	}
+	if e, a := []string{"other"}, drained; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected %#v, got %#v", e, a)
+	}
+}
@@ -0,0 +1,77 @@
+package idtoken
+
+import (
+	"fmt"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// Claims are the set of OpenID Connect core claims carried in an ID Token.
+// Unexported/extra claims are intentionally omitted; scope-derived claims
+// (email, profile) are added alongside these by the caller as needed.
+type Claims struct {
+	Issuer   string `json:"iss"`
+	Subject  string `json:"sub"`
+	Audience string `json:"aud"`
+	Expiry   int64  `json:"exp"`
+	IssuedAt int64  `json:"iat"`
+	Nonce    string `json:"nonce,omitempty"`
+
+	Email   string `json:"email,omitempty"`
+	Profile string `json:"profile,omitempty"`
+}
+
+// ClaimsForToken builds the ID Token claim set for the given AccessToken, scoped down to the
+// claims its requested Scopes entitle it to ("email" requires the "email" scope, "profile"
+// requires the "profile" scope, per OIDC core ยง5.4). It returns an error if client is not
+// allowed to receive an IDToken at all: per client.AllowedGrantTypes, a client must include
+// "implicit" or "authorization_code" there and the token must have requested the "openid"
+// scope.
+func ClaimsForToken(issuer string, client *api.Client, token *api.AccessToken, authorize *api.AuthorizeToken, email, profile string) (Claims, error) {
+	if !hasScope(token.Scopes, "openid") {
+		return Claims{}, fmt.Errorf("token does not carry the %q scope required to receive an IDToken", "openid")
+	}
+	if !hasGrantType(client.AllowedGrantTypes, "implicit") && !hasGrantType(client.AllowedGrantTypes, "authorization_code") {
+		return Claims{}, fmt.Errorf("client %q is not allowed to receive an IDToken: AllowedGrantTypes must include \"implicit\" or \"authorization_code\"", client.Name)
+	}
+
+	claims := Claims{
+		Issuer:   issuer,
+		Subject:  token.UserUID,
+		Audience: token.ClientName,
+		IssuedAt: token.CreationTimestamp.Unix(),
+		Expiry:   token.CreationTimestamp.Unix() + token.ExpiresIn,
+	}
+	if authorize != nil {
+		claims.Nonce = authorize.Nonce
+	}
+	for _, scope := range token.Scopes {
+		switch scope {
+		case "email":
+			claims.Email = email
+		case "profile":
+			claims.Profile = profile
+		}
+	}
+	return claims, nil
+}
+
+// hasScope returns whether scopes contains scope.
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGrantType returns whether grantTypes contains grantType.
+func hasGrantType(grantTypes []string, grantType string) bool {
+	for _, g := range grantTypes {
+		if g == grantType {
+			return true
+		}
+	}
+	return false
+}
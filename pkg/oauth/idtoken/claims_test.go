@@ -0,0 +1,70 @@
+package idtoken
+
+import (
+	"testing"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+func TestClaimsForToken(t *testing.T) {
+	now := util.Now()
+	client := &api.Client{
+		ObjectMeta:        kapi.ObjectMeta{Name: "myclient"},
+		AllowedGrantTypes: []string{"authorization_code"},
+	}
+	token := &api.AccessToken{
+		ObjectMeta:        kapi.ObjectMeta{CreationTimestamp: now},
+		ClientName:        "myclient",
+		UserUID:           "user-uid",
+		ExpiresIn:         3600,
+		Scopes:            []string{"openid", "email", "profile"},
+	}
+	authorize := &api.AuthorizeToken{Nonce: "abc"}
+
+	claims, err := ClaimsForToken("https://example.com", client, token, authorize, "bob@example.com", "bob")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Issuer != "https://example.com" || claims.Subject != "user-uid" || claims.Audience != "myclient" {
+		t.Errorf("unexpected claims: %#v", claims)
+	}
+	if claims.Nonce != "abc" {
+		t.Errorf("expected nonce to be carried over from the AuthorizeToken, got: %q", claims.Nonce)
+	}
+	if claims.Email != "bob@example.com" || claims.Profile != "bob" {
+		t.Errorf("expected email/profile claims for a token with the email/profile scopes, got: %#v", claims)
+	}
+	if claims.Expiry != now.Unix()+3600 {
+		t.Errorf("expected expiry to be CreationTimestamp+ExpiresIn, got: %d", claims.Expiry)
+	}
+}
+
+func TestClaimsForTokenRejectsMissingOpenIDScope(t *testing.T) {
+	client := &api.Client{AllowedGrantTypes: []string{"authorization_code"}}
+	token := &api.AccessToken{Scopes: []string{"email"}}
+
+	if _, err := ClaimsForToken("https://example.com", client, token, nil, "", ""); err == nil {
+		t.Errorf("expected an error for a token that did not request the openid scope")
+	}
+}
+
+func TestClaimsForTokenRejectsDisallowedGrantType(t *testing.T) {
+	client := &api.Client{AllowedGrantTypes: []string{"client_credentials"}}
+	token := &api.AccessToken{Scopes: []string{"openid"}}
+
+	if _, err := ClaimsForToken("https://example.com", client, token, nil, "", ""); err == nil {
+		t.Errorf("expected an error for a client whose AllowedGrantTypes does not include implicit or authorization_code")
+	}
+}
+
+func TestClaimsForTokenAllowsImplicitGrantType(t *testing.T) {
+	client := &api.Client{AllowedGrantTypes: []string{"implicit"}}
+	token := &api.AccessToken{Scopes: []string{"openid"}}
+
+	if _, err := ClaimsForToken("https://example.com", client, token, nil, "", ""); err != nil {
+		t.Errorf("unexpected error for a client allowing the implicit grant type: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package idtoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+)
+
+// jwk is the JSON representation of a single RSA public key, per RFC 7517.
+type jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// jwks is a JSON Web Key Set, per RFC 7517 ยง5.
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// KeysHandler serves the active public keys in a KeySet as a JWKS document at
+// the well-known "/keys" path referenced by the discovery document.
+type KeysHandler struct {
+	keys *KeySet
+}
+
+// NewKeysHandler returns a handler for the given KeySet.
+func NewKeysHandler(keys *KeySet) *KeysHandler {
+	return &KeysHandler{keys: keys}
+}
+
+func (h *KeysHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	set := jwks{}
+	for kid, key := range h.keys.PublicKeys() {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(set)
+}
+
+// DiscoveryDocument is the subset of the OpenID Connect discovery document
+// ("/.well-known/openid-configuration") this server publishes.
+type DiscoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	AuthorizationEndpoint            string   `json:"authorization_endpoint"`
+	TokenEndpoint                    string   `json:"token_endpoint"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ResponseTypesSupported           []string `json:"response_types_supported"`
+	SubjectTypesSupported            []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                  []string `json:"scopes_supported"`
+}
+
+// DiscoveryHandler serves the OpenID Connect discovery document describing this server.
+type DiscoveryHandler struct {
+	doc DiscoveryDocument
+}
+
+// NewDiscoveryHandler returns a handler that serves doc as the discovery document.
+func NewDiscoveryHandler(doc DiscoveryDocument) *DiscoveryHandler {
+	return &DiscoveryHandler{doc: doc}
+}
+
+func (h *DiscoveryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.doc)
+}
@@ -0,0 +1,99 @@
+package idtoken
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKeysHandlerServesActivePublicKeys(t *testing.T) {
+	set := NewKeySet()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %v", err)
+	}
+	set.AddKey("2015-01-01", key)
+
+	handler := NewKeysHandler(set)
+	req, err := http.NewRequest("GET", "/keys", nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %v", err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("unexpected Content-Type: %q", ct)
+	}
+
+	var body jwks
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	if len(body.Keys) != 1 {
+		t.Fatalf("expected exactly one key, got %d", len(body.Keys))
+	}
+	k := body.Keys[0]
+	if k.Kty != "RSA" || k.Use != "sig" || k.Alg != "RS256" || k.Kid != "2015-01-01" {
+		t.Errorf("unexpected key fields: %#v", k)
+	}
+	if k.N == "" || k.E == "" {
+		t.Errorf("expected non-empty modulus/exponent, got: %#v", k)
+	}
+}
+
+func TestKeysHandlerServesEmptySetWithNoActiveKeys(t *testing.T) {
+	handler := NewKeysHandler(NewKeySet())
+	req, err := http.NewRequest("GET", "/keys", nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %v", err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	var body jwks
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	if len(body.Keys) != 0 {
+		t.Errorf("expected no keys, got %#v", body.Keys)
+	}
+}
+
+func TestDiscoveryHandlerServesDocument(t *testing.T) {
+	doc := DiscoveryDocument{
+		Issuer:                           "https://example.com",
+		AuthorizationEndpoint:            "https://example.com/oauth/authorize",
+		TokenEndpoint:                    "https://example.com/oauth/token",
+		JWKSURI:                          "https://example.com/oauth/keys",
+		ResponseTypesSupported:           []string{"code", "id_token"},
+		SubjectTypesSupported:            []string{"public"},
+		IDTokenSigningAlgValuesSupported: []string{"RS256"},
+		ScopesSupported:                  []string{"openid", "email", "profile"},
+	}
+	handler := NewDiscoveryHandler(doc)
+	req, err := http.NewRequest("GET", "/.well-known/openid-configuration", nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %v", err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("unexpected Content-Type: %q", ct)
+	}
+
+	var got DiscoveryDocument
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	if got.Issuer != doc.Issuer || got.JWKSURI != doc.JWKSURI {
+		t.Errorf("unexpected discovery document: %#v", got)
+	}
+	if len(got.ScopesSupported) != len(doc.ScopesSupported) {
+		t.Errorf("unexpected ScopesSupported: %#v", got.ScopesSupported)
+	}
+}
@@ -0,0 +1,68 @@
+package idtoken
+
+import (
+	"crypto/rsa"
+	"fmt"
+	"sync"
+)
+
+// KeySet holds every RSA key the server is currently willing to sign or verify
+// ID Tokens with, indexed by kid. Multiple keys may be active at once during a
+// rotation: new tokens are always signed with the current key, but tokens
+// signed with a key that was retired only recently can still be verified by
+// anyone consulting the JWKS endpoint until that key is dropped from the set.
+type KeySet struct {
+	mu      sync.RWMutex
+	current string
+	keys    map[string]*rsa.PrivateKey
+}
+
+// NewKeySet returns an empty KeySet.
+func NewKeySet() *KeySet {
+	return &KeySet{keys: map[string]*rsa.PrivateKey{}}
+}
+
+// AddKey adds or replaces the key for kid and makes it the current signing key.
+func (s *KeySet) AddKey(kid string, key *rsa.PrivateKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[kid] = key
+	s.current = kid
+}
+
+// RemoveKey retires kid; tokens it previously signed can no longer be verified
+// against this server's JWKS once this is called.
+func (s *KeySet) RemoveKey(kid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, kid)
+}
+
+// Signer returns a Signer for the current signing key, provided alg is either empty
+// (defaulting to "RS256") or "RS256" itself, since RS256 is the only alg this KeySet
+// can currently produce; any other value is rejected rather than silently signed with
+// the wrong alg, per Client.IDTokenSignedResponseAlg's contract.
+func (s *KeySet) Signer(alg string) (*Signer, error) {
+	if alg != "" && alg != "RS256" {
+		return nil, fmt.Errorf("unsupported id_token_signed_response_alg %q: only \"RS256\" is supported", alg)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.current == "" {
+		return nil, fmt.Errorf("no active id_token signing key")
+	}
+	return NewSigner(s.current, s.keys[s.current]), nil
+}
+
+// PublicKeys returns every active public key, keyed by kid, suitable for
+// serialization onto the JWKS endpoint.
+func (s *KeySet) PublicKeys() map[string]*rsa.PublicKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]*rsa.PublicKey, len(s.keys))
+	for kid, key := range s.keys {
+		out[kid] = &key.PublicKey
+	}
+	return out
+}
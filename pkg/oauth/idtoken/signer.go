@@ -0,0 +1,58 @@
+package idtoken
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// header is the JOSE header of an RS256-signed compact JWS.
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+// Signer produces RS256-signed, compact-serialized JWTs using a single RSA key.
+// A KeySet wraps one Signer per active key so that multiple kids can be valid
+// at once during rotation.
+type Signer struct {
+	kid string
+	key *rsa.PrivateKey
+}
+
+// NewSigner returns a Signer that signs with key and identifies it as kid in the
+// JOSE header, so verifiers can look up the matching public key via the JWKS endpoint.
+func NewSigner(kid string, key *rsa.PrivateKey) *Signer {
+	return &Signer{kid: kid, key: key}
+}
+
+// Sign produces a compact-serialized RS256 JWS over claims.
+func (s *Signer) Sign(claims Claims) (string, error) {
+	headerJSON, err := json.Marshal(header{Alg: "RS256", Kid: s.kid, Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(headerJSON) + "." + encodeSegment(claimsJSON)
+
+	digest := sha256.Sum256([]byte(signingInput))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("signing id_token: %v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,87 @@
+package idtoken
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestSignerSignVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %v", err)
+	}
+
+	signer := NewSigner("2015-01-01", key)
+	claims := Claims{Issuer: "https://example.com", Subject: "user-uid", Audience: "client", Nonce: "abc"}
+
+	token, err := signer.Sign(claims)
+	if err != nil {
+		t.Fatalf("unexpected error signing: %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a 3-segment compact JWS, got %d segments: %s", len(parts), token)
+	}
+
+	signingInput := parts[0] + "." + parts[1]
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("unexpected error decoding signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(signingInput))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig); err != nil {
+		t.Errorf("signature did not verify: %v", err)
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	set := NewKeySet()
+	key1, _ := rsa.GenerateKey(rand.Reader, 2048)
+	key2, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	set.AddKey("key1", key1)
+	signer, err := set.Signer("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := signer.Sign(Claims{}); err != nil {
+		t.Errorf("unexpected error signing with key1: %v", err)
+	}
+
+	set.AddKey("key2", key2)
+	if len(set.PublicKeys()) != 2 {
+		t.Errorf("expected both keys to remain active during rotation, got %d", len(set.PublicKeys()))
+	}
+
+	signer, err = set.Signer("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := signer.Sign(Claims{}); err != nil {
+		t.Errorf("unexpected error signing with key2: %v", err)
+	}
+
+	set.RemoveKey("key1")
+	if len(set.PublicKeys()) != 1 {
+		t.Errorf("expected key1 to be retired, got %d keys", len(set.PublicKeys()))
+	}
+}
+
+func TestKeySetSignerRejectsUnsupportedAlg(t *testing.T) {
+	set := NewKeySet()
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	set.AddKey("key1", key)
+
+	if _, err := set.Signer("HS256"); err == nil {
+		t.Errorf("expected an error for an unsupported id_token_signed_response_alg")
+	}
+	if _, err := set.Signer("RS256"); err != nil {
+		t.Errorf("unexpected error for the supported RS256 alg: %v", err)
+	}
+}
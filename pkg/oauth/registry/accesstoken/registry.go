@@ -0,0 +1,20 @@
+package accesstoken
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// Registry is an interface implemented by things that know how to store AccessToken objects.
+type Registry interface {
+	// ListAccessTokens obtains a list of access tokens that match a selector.
+	ListAccessTokens(ctx kapi.Context, label, field labels.Selector) (*api.AccessTokenList, error)
+	// GetAccessToken retrieves a specific access token.
+	GetAccessToken(ctx kapi.Context, name string) (*api.AccessToken, error)
+	// CreateAccessToken creates a new access token.
+	CreateAccessToken(ctx kapi.Context, token *api.AccessToken) error
+	// DeleteAccessToken deletes an access token.
+	DeleteAccessToken(ctx kapi.Context, name string) error
+}
@@ -0,0 +1,20 @@
+package authorizetoken
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// Registry is an interface implemented by things that know how to store AuthorizeToken objects.
+type Registry interface {
+	// ListAuthorizeTokens obtains a list of authorize tokens that match a selector.
+	ListAuthorizeTokens(ctx kapi.Context, label, field labels.Selector) (*api.AuthorizeTokenList, error)
+	// GetAuthorizeToken retrieves a specific authorize token.
+	GetAuthorizeToken(ctx kapi.Context, name string) (*api.AuthorizeToken, error)
+	// CreateAuthorizeToken creates a new authorize token.
+	CreateAuthorizeToken(ctx kapi.Context, token *api.AuthorizeToken) error
+	// DeleteAuthorizeToken deletes an authorize token.
+	DeleteAuthorizeToken(ctx kapi.Context, name string) error
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// Registry is an interface implemented by things that know how to store Client objects.
+type Registry interface {
+	// ListClients obtains a list of clients that match a selector.
+	ListClients(ctx kapi.Context, label, field labels.Selector) (*api.ClientList, error)
+	// GetClient retrieves a specific client.
+	GetClient(ctx kapi.Context, name string) (*api.Client, error)
+	// CreateClient creates a new client.
+	CreateClient(ctx kapi.Context, client *api.Client) error
+	// UpdateClient updates a client, performing a compare-and-swap against the
+	// stored ResourceVersion to detect conflicting concurrent updates.
+	UpdateClient(ctx kapi.Context, client *api.Client) error
+	// DeleteClient deletes a client.
+	DeleteClient(ctx kapi.Context, name string) error
+}
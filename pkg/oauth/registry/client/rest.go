@@ -0,0 +1,236 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/apiserver"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/runtime"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/api/validation"
+	"github.com/openshift/origin/pkg/oauth/registry/authorizetoken"
+)
+
+// REST implements the RESTStorage interface for Client objects against the given Registry.
+type REST struct {
+	registry        Registry
+	authorizeTokens authorizetoken.Registry
+}
+
+// NewREST returns a new REST for clients, backed by the given registry. Validation
+// of RedirectURI removal against outstanding AuthorizeTokens is disabled; use
+// NewRESTWithAuthorizeTokens to enable it.
+func NewREST(registry Registry) *REST {
+	return &REST{registry: registry}
+}
+
+// NewRESTWithAuthorizeTokens returns a new REST for clients, backed by the given
+// registry, that also consults authorizeTokens so that ValidateClientUpdate can
+// reject removal of a RedirectURI still referenced by an outstanding AuthorizeToken.
+func NewRESTWithAuthorizeTokens(registry Registry, authorizeTokens authorizetoken.Registry) *REST {
+	return &REST{registry: registry, authorizeTokens: authorizeTokens}
+}
+
+// New returns a new Client for use with Create and Update.
+func (r *REST) New() runtime.Object {
+	return &api.Client{}
+}
+
+// Get retrieves a client by name.
+func (r *REST) Get(ctx kapi.Context, name string) (runtime.Object, error) {
+	return r.registry.GetClient(ctx, name)
+}
+
+// List retrieves the clients that match the given selectors.
+func (r *REST) List(ctx kapi.Context, label, field labels.Selector) (runtime.Object, error) {
+	return r.registry.ListClients(ctx, label, field)
+}
+
+// Create registers the given Client.
+func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (<-chan apiserver.RESTResult, error) {
+	client, ok := obj.(*api.Client)
+	if !ok {
+		return nil, fmt.Errorf("not a client: %#v", obj)
+	}
+
+	if errs := validation.ValidateClient(client); len(errs) > 0 {
+		return nil, kerrors.NewInvalid("client", client.Name, errs)
+	}
+
+	kapi.FillObjectMetaSystemFields(ctx, &client.ObjectMeta)
+
+	if !hasFinalizer(client.Finalizers, api.FinalizerOAuthTokens) {
+		client.Finalizers = append(client.Finalizers, api.FinalizerOAuthTokens)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if err := r.registry.CreateClient(ctx, client); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}), nil
+}
+
+// Update performs a compare-and-swap update of the given Client. name is the
+// resource name from the request URL; it is looked up separately from
+// client.Name (the request body's own name field) so that a body attempting
+// to rename the client is fetched against the existing object under its real
+// name, letting ValidateClientUpdate's name-immutability check actually catch
+// the mismatch instead of comparing an object to itself. If the stored
+// Client's ResourceVersion does not match the one on obj, a Conflict status is
+// returned instead of applying the update.
+func (r *REST) Update(ctx kapi.Context, name string, obj runtime.Object) (<-chan apiserver.RESTResult, error) {
+	client, ok := obj.(*api.Client)
+	if !ok {
+		return nil, fmt.Errorf("not a client: %#v", obj)
+	}
+
+	old, err := r.registry.GetClient(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if client.ResourceVersion != old.ResourceVersion {
+		return nil, kerrors.NewConflict("client", client.Name, fmt.Errorf("the object has been modified; please apply your changes to the latest version and try again"))
+	}
+
+	outstanding, err := r.outstandingRedirectURIs(ctx, old)
+	if err != nil {
+		return nil, err
+	}
+
+	if errs := validation.ValidateClientUpdate(client, old, outstanding); len(errs) > 0 {
+		return nil, kerrors.NewInvalid("client", client.Name, errs)
+	}
+
+	// preserve the existing secret unless the caller is explicitly rotating it
+	// via the RotateSecret sub-resource.
+	if len(client.Secret) == 0 {
+		client.Secret = old.Secret
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		if err := r.registry.UpdateClient(ctx, client); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}), nil
+}
+
+// Delete removes the given Client. If the client has outstanding finalizers
+// (set by controllers such as the token garbage collector) and the caller has
+// not requested orphan propagation, the client is not removed immediately;
+// instead its DeletionTimestamp is set and it is persisted so that those
+// controllers can observe the pending deletion, clean up their dependents,
+// and drain their finalizer entries.
+func (r *REST) Delete(ctx kapi.Context, name string, options *api.DeleteOptions) (<-chan apiserver.RESTResult, error) {
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		client, err := r.registry.GetClient(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		orphan := options != nil && options.PropagationPolicy != nil && *options.PropagationPolicy == api.DeletePropagationOrphan
+
+		if orphan || len(client.Finalizers) == 0 {
+			return &kapi.Status{Status: kapi.StatusSuccess}, r.registry.DeleteClient(ctx, name)
+		}
+
+		now := util.Now()
+		client.DeletionTimestamp = &now
+		if err := r.registry.UpdateClient(ctx, client); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}), nil
+}
+
+// outstandingRedirectURIs returns the set of RedirectURIs that are still referenced by an
+// AuthorizeToken issued against this client, and therefore cannot be removed on update. It
+// returns an empty set, rather than an error, if no AuthorizeToken registry was configured.
+func (r *REST) outstandingRedirectURIs(ctx kapi.Context, client *api.Client) (map[string]bool, error) {
+	outstanding := map[string]bool{}
+	if r.authorizeTokens == nil {
+		return outstanding, nil
+	}
+
+	selector := labels.Set{"clientName": client.Name}.AsSelector()
+	tokens, err := r.authorizeTokens.ListAuthorizeTokens(ctx, labels.Everything(), selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, token := range tokens.Items {
+		outstanding[token.RedirectURI] = true
+	}
+	return outstanding, nil
+}
+
+// hasFinalizer returns whether finalizers contains name.
+func hasFinalizer(finalizers []string, name string) bool {
+	for _, f := range finalizers {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// generateSecret returns a cryptographically random, URL-safe client secret.
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// RotateSecretREST implements the "clients/rotatesecret" sub-resource. A Create
+// against it generates a new, cryptographically-random secret for the named
+// client, persists it, and returns the new value. The value is never stored
+// anywhere else and is not retrievable again once returned.
+type RotateSecretREST struct {
+	registry Registry
+}
+
+// NewRotateSecretREST returns a new RotateSecretREST backed by the given registry.
+func NewRotateSecretREST(registry Registry) *RotateSecretREST {
+	return &RotateSecretREST{registry: registry}
+}
+
+// New returns a new Client to decode a RotateSecret request into.
+func (r *RotateSecretREST) New() runtime.Object {
+	return &api.Client{}
+}
+
+// Create rotates the secret of the named client and returns the updated Client,
+// whose Secret field holds the new value exactly once.
+func (r *RotateSecretREST) Create(ctx kapi.Context, obj runtime.Object) (<-chan apiserver.RESTResult, error) {
+	in, ok := obj.(*api.Client)
+	if !ok {
+		return nil, fmt.Errorf("not a client: %#v", obj)
+	}
+
+	return apiserver.MakeAsync(func() (runtime.Object, error) {
+		client, err := r.registry.GetClient(ctx, in.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		client.Secret = secret
+
+		if err := r.registry.UpdateClient(ctx, client); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}), nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	kerrors "github.com/GoogleCloudPlatform/kubernetes/pkg/api/errors"
 	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
 	oapi "github.com/openshift/origin/pkg/oauth/api"
 	"github.com/openshift/origin/pkg/oauth/registry/test"
@@ -17,7 +18,7 @@ func TestCreateValidationError(t *testing.T) {
 		registry: &registry,
 	}
 	client := &oapi.Client{
-	// ObjectMeta: api.ObjectMeta{Name: "authTokenName"}, // Missing required field
+		// ObjectMeta: api.ObjectMeta{Name: "authTokenName"}, // Missing required field
 	}
 
 	ctx := api.NewContext()
@@ -91,6 +92,67 @@ func TestCreateValid(t *testing.T) {
 	}
 }
 
+func TestCreateSetsDefaultFinalizer(t *testing.T) {
+	registry := test.ClientRegistry{}
+	storage := REST{
+		registry: &registry,
+	}
+	client := &oapi.Client{
+		ObjectMeta: api.ObjectMeta{Name: "clientName"},
+	}
+
+	ctx := api.NewContext()
+	channel, err := storage.Create(ctx, client)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	<-channel
+
+	if registry.CreatedClient == nil || !hasFinalizer(registry.CreatedClient.Finalizers, oapi.FinalizerOAuthTokens) {
+		t.Errorf("expected FinalizerOAuthTokens to be stamped on create, got: %#v", registry.CreatedClient)
+	}
+}
+
+func TestCreateThenDeleteBlocksOnFinalizer(t *testing.T) {
+	registry := test.ClientRegistry{}
+	storage := REST{
+		registry: &registry,
+	}
+	client := &oapi.Client{
+		ObjectMeta: api.ObjectMeta{Name: "clientName"},
+	}
+
+	ctx := api.NewContext()
+	createChannel, err := storage.Create(ctx, client)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	<-createChannel
+
+	deleteChannel, err := storage.Delete(ctx, "clientName", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	select {
+	case r := <-deleteChannel:
+		switch r := r.Object.(type) {
+		case *oapi.Client:
+			if r.DeletionTimestamp == nil {
+				t.Errorf("expected DeletionTimestamp to be set, got: %#v", r)
+			}
+		default:
+			t.Errorf("Got unexpected type: %#v", r)
+		}
+	case <-time.After(time.Millisecond * 100):
+		t.Error("Unexpected timeout from async channel")
+	}
+
+	if registry.DeletedClientName != "" {
+		t.Errorf("expected a client created through the normal path not to be hard-deleted immediately")
+	}
+}
+
 func TestGetError(t *testing.T) {
 	registry := test.ClientRegistry{
 		Err: errors.New("Sample Error"),
@@ -203,26 +265,204 @@ func TestList(t *testing.T) {
 	}
 }
 
-func TestUpdateNotSupported(t *testing.T) {
+func TestUpdateConflict(t *testing.T) {
 	registry := test.ClientRegistry{
-		Err: errors.New("Storage Error"),
+		Client: &oapi.Client{
+			ObjectMeta: api.ObjectMeta{Name: "clientName", ResourceVersion: "1"},
+		},
 	}
 	storage := REST{
 		registry: &registry,
 	}
 	client := &oapi.Client{
-		ObjectMeta: api.ObjectMeta{Name: "clientName"},
+		ObjectMeta: api.ObjectMeta{Name: "clientName", ResourceVersion: "2"},
 	}
 
 	ctx := api.NewContext()
-	_, err := storage.Update(ctx, client)
+	_, err := storage.Update(ctx, client.Name, client)
 	if err == nil {
-		t.Errorf("expected unsupported error, but update succeeded")
+		t.Errorf("expected conflict error, but update succeeded")
 		return
 	}
-	if err == registry.Err {
-		t.Errorf("expected unsupported error, but registry was called")
-		return
+	if !kerrors.IsConflict(err) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+	if registry.UpdatedClient != nil {
+		t.Errorf("expected registry not to be called, but it was: %#v", registry.UpdatedClient)
+	}
+}
+
+func TestUpdateValid(t *testing.T) {
+	registry := test.ClientRegistry{
+		Client: &oapi.Client{
+			ObjectMeta: api.ObjectMeta{Name: "clientName", ResourceVersion: "1"},
+			Secret:     "oldsecret",
+		},
+	}
+	storage := REST{
+		registry: &registry,
+	}
+	client := &oapi.Client{
+		ObjectMeta:   api.ObjectMeta{Name: "clientName", ResourceVersion: "1"},
+		RedirectURIs: []string{"https://example.com/callback"},
+	}
+
+	ctx := api.NewContext()
+	channel, err := storage.Update(ctx, client.Name, client)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	select {
+	case r := <-channel:
+		switch r := r.Object.(type) {
+		case *api.Status:
+			t.Errorf("Got back unexpected status: %#v", r)
+		case *oapi.Client:
+			if r.Secret != "oldsecret" {
+				t.Errorf("expected secret to be preserved, got: %v", r.Secret)
+			}
+		default:
+			t.Errorf("Got unexpected type: %#v", r)
+		}
+	case <-time.After(time.Millisecond * 100):
+		t.Error("Unexpected timeout from async channel")
+	}
+	if registry.UpdatedClient == nil {
+		t.Errorf("expected registry to be called")
+	}
+}
+
+func TestUpdateRejectsRemovalOfOutstandingRedirectURI(t *testing.T) {
+	registry := test.ClientRegistry{
+		Client: &oapi.Client{
+			ObjectMeta:   api.ObjectMeta{Name: "clientName", ResourceVersion: "1"},
+			RedirectURIs: []string{"https://example.com/old", "https://example.com/new"},
+		},
+	}
+	authorizeTokens := test.AuthorizeTokenRegistry{
+		Tokens: &oapi.AuthorizeTokenList{Items: []oapi.AuthorizeToken{
+			{ClientName: "clientName", RedirectURI: "https://example.com/old"},
+		}},
+	}
+	storage := REST{
+		registry:        &registry,
+		authorizeTokens: &authorizeTokens,
+	}
+	client := &oapi.Client{
+		ObjectMeta:   api.ObjectMeta{Name: "clientName", ResourceVersion: "1"},
+		RedirectURIs: []string{"https://example.com/new"},
+	}
+
+	ctx := api.NewContext()
+	_, err := storage.Update(ctx, client.Name, client)
+	if err == nil {
+		t.Fatalf("expected an error rejecting removal of an outstanding RedirectURI")
+	}
+	if !kerrors.IsInvalid(err) {
+		t.Errorf("expected an invalid error, got: %v", err)
+	}
+	if registry.UpdatedClient != nil {
+		t.Errorf("expected registry not to be called, but it was: %#v", registry.UpdatedClient)
+	}
+}
+
+func TestUpdateAllowsRemovalOfRedirectURIWithNoOutstandingToken(t *testing.T) {
+	registry := test.ClientRegistry{
+		Client: &oapi.Client{
+			ObjectMeta:   api.ObjectMeta{Name: "clientName", ResourceVersion: "1"},
+			RedirectURIs: []string{"https://example.com/old", "https://example.com/new"},
+		},
+	}
+	authorizeTokens := test.AuthorizeTokenRegistry{
+		Tokens: &oapi.AuthorizeTokenList{Items: []oapi.AuthorizeToken{
+			{ClientName: "clientName", RedirectURI: "https://example.com/new"},
+		}},
+	}
+	storage := REST{
+		registry:        &registry,
+		authorizeTokens: &authorizeTokens,
+	}
+	client := &oapi.Client{
+		ObjectMeta:   api.ObjectMeta{Name: "clientName", ResourceVersion: "1"},
+		RedirectURIs: []string{"https://example.com/new"},
+	}
+
+	ctx := api.NewContext()
+	channel, err := storage.Update(ctx, client.Name, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-channel
+
+	if registry.UpdatedClient == nil {
+		t.Errorf("expected registry to be called")
+	}
+}
+
+func TestUpdateRejectsRename(t *testing.T) {
+	registry := test.ClientRegistry{
+		Client: &oapi.Client{
+			ObjectMeta: api.ObjectMeta{Name: "clientName", ResourceVersion: "1"},
+		},
+	}
+	storage := REST{
+		registry: &registry,
+	}
+	client := &oapi.Client{
+		ObjectMeta: api.ObjectMeta{Name: "renamedClient", ResourceVersion: "1"},
+	}
+
+	ctx := api.NewContext()
+	_, err := storage.Update(ctx, "clientName", client)
+	if err == nil {
+		t.Fatalf("expected an error rejecting the rename")
+	}
+	if !kerrors.IsInvalid(err) {
+		t.Errorf("expected an invalid error, got: %v", err)
+	}
+	if registry.UpdatedClient != nil {
+		t.Errorf("expected registry not to be called, but it was: %#v", registry.UpdatedClient)
+	}
+}
+
+func TestRotateSecret(t *testing.T) {
+	registry := test.ClientRegistry{
+		Client: &oapi.Client{
+			ObjectMeta: api.ObjectMeta{Name: "clientName"},
+			Secret:     "oldsecret",
+		},
+	}
+	storage := RotateSecretREST{
+		registry: &registry,
+	}
+	client := &oapi.Client{
+		ObjectMeta: api.ObjectMeta{Name: "clientName"},
+	}
+
+	ctx := api.NewContext()
+	channel, err := storage.Create(ctx, client)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	select {
+	case r := <-channel:
+		switch r := r.Object.(type) {
+		case *api.Status:
+			t.Errorf("Got back unexpected status: %#v", r)
+		case *oapi.Client:
+			if r.Secret == "oldsecret" || len(r.Secret) == 0 {
+				t.Errorf("expected a freshly generated secret, got: %v", r.Secret)
+			}
+		default:
+			t.Errorf("Got unexpected type: %#v", r)
+		}
+	case <-time.After(time.Millisecond * 100):
+		t.Error("Unexpected timeout from async channel")
+	}
+	if registry.UpdatedClient == nil || registry.UpdatedClient.Secret == "oldsecret" {
+		t.Errorf("expected registry to be updated with the new secret")
 	}
 }
 
@@ -235,7 +475,7 @@ func TestDeleteError(t *testing.T) {
 	}
 
 	ctx := api.NewContext()
-	channel, err := storage.Delete(ctx, "foo")
+	channel, err := storage.Delete(ctx, "foo", nil)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -258,13 +498,17 @@ func TestDeleteError(t *testing.T) {
 }
 
 func TestDeleteValid(t *testing.T) {
-	registry := test.ClientRegistry{}
+	registry := test.ClientRegistry{
+		Client: &oapi.Client{
+			ObjectMeta: api.ObjectMeta{Name: "foo"},
+		},
+	}
 	storage := REST{
 		registry: &registry,
 	}
 
 	ctx := api.NewContext()
-	channel, err := storage.Delete(ctx, "foo")
+	channel, err := storage.Delete(ctx, "foo", nil)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -287,3 +531,66 @@ func TestDeleteValid(t *testing.T) {
 		t.Error("Unexpected client deleted: %s", registry.DeletedClientName)
 	}
 }
+
+func TestDeleteWithFinalizersBlocks(t *testing.T) {
+	registry := test.ClientRegistry{
+		Client: &oapi.Client{
+			ObjectMeta: api.ObjectMeta{Name: "foo"},
+			Finalizers: []string{oapi.FinalizerOAuthTokens},
+		},
+	}
+	storage := REST{
+		registry: &registry,
+	}
+
+	ctx := api.NewContext()
+	channel, err := storage.Delete(ctx, "foo", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	select {
+	case r := <-channel:
+		switch r := r.Object.(type) {
+		case *oapi.Client:
+			if r.DeletionTimestamp == nil {
+				t.Errorf("expected DeletionTimestamp to be set, got: %#v", r)
+			}
+		default:
+			t.Errorf("Got unexpected type: %#v", r)
+		}
+	case <-time.After(time.Millisecond * 100):
+		t.Error("Unexpected timeout from async channel")
+	}
+
+	if registry.DeletedClientName != "" {
+		t.Errorf("expected client not to be hard-deleted while finalizers remain")
+	}
+	if registry.UpdatedClient == nil || registry.UpdatedClient.DeletionTimestamp == nil {
+		t.Errorf("expected registry to be updated with a DeletionTimestamp")
+	}
+}
+
+func TestDeleteOrphanIgnoresFinalizers(t *testing.T) {
+	registry := test.ClientRegistry{
+		Client: &oapi.Client{
+			ObjectMeta: api.ObjectMeta{Name: "foo"},
+			Finalizers: []string{oapi.FinalizerOAuthTokens},
+		},
+	}
+	storage := REST{
+		registry: &registry,
+	}
+
+	policy := oapi.DeletePropagationOrphan
+	ctx := api.NewContext()
+	channel, err := storage.Delete(ctx, "foo", &oapi.DeleteOptions{PropagationPolicy: &policy})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	<-channel
+
+	if registry.DeletedClientName != "foo" {
+		t.Errorf("expected orphan propagation to delete the client immediately")
+	}
+}
@@ -0,0 +1,20 @@
+package clientauthorization
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// Registry is an interface implemented by things that know how to store ClientAuthorization objects.
+type Registry interface {
+	// ListClientAuthorizations obtains a list of client authorizations that match a selector.
+	ListClientAuthorizations(ctx kapi.Context, label, field labels.Selector) (*api.ClientAuthorizationList, error)
+	// GetClientAuthorization retrieves a specific client authorization.
+	GetClientAuthorization(ctx kapi.Context, name string) (*api.ClientAuthorization, error)
+	// CreateClientAuthorization creates a new client authorization.
+	CreateClientAuthorization(ctx kapi.Context, auth *api.ClientAuthorization) error
+	// DeleteClientAuthorization deletes a client authorization.
+	DeleteClientAuthorization(ctx kapi.Context, name string) error
+}
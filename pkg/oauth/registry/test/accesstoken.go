@@ -0,0 +1,36 @@
+package test
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// AccessTokenRegistry is a test double implementing accesstoken.Registry.
+type AccessTokenRegistry struct {
+	Err    error
+	Token  *api.AccessToken
+	Tokens *api.AccessTokenList
+
+	CreatedToken     *api.AccessToken
+	DeletedTokenName string
+}
+
+func (r *AccessTokenRegistry) ListAccessTokens(ctx kapi.Context, label, field labels.Selector) (*api.AccessTokenList, error) {
+	return r.Tokens, r.Err
+}
+
+func (r *AccessTokenRegistry) GetAccessToken(ctx kapi.Context, name string) (*api.AccessToken, error) {
+	return r.Token, r.Err
+}
+
+func (r *AccessTokenRegistry) CreateAccessToken(ctx kapi.Context, token *api.AccessToken) error {
+	r.CreatedToken = token
+	return r.Err
+}
+
+func (r *AccessTokenRegistry) DeleteAccessToken(ctx kapi.Context, name string) error {
+	r.DeletedTokenName = name
+	return r.Err
+}
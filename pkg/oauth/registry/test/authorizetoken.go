@@ -0,0 +1,36 @@
+package test
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// AuthorizeTokenRegistry is a test double implementing authorizetoken.Registry.
+type AuthorizeTokenRegistry struct {
+	Err    error
+	Token  *api.AuthorizeToken
+	Tokens *api.AuthorizeTokenList
+
+	CreatedToken     *api.AuthorizeToken
+	DeletedTokenName string
+}
+
+func (r *AuthorizeTokenRegistry) ListAuthorizeTokens(ctx kapi.Context, label, field labels.Selector) (*api.AuthorizeTokenList, error) {
+	return r.Tokens, r.Err
+}
+
+func (r *AuthorizeTokenRegistry) GetAuthorizeToken(ctx kapi.Context, name string) (*api.AuthorizeToken, error) {
+	return r.Token, r.Err
+}
+
+func (r *AuthorizeTokenRegistry) CreateAuthorizeToken(ctx kapi.Context, token *api.AuthorizeToken) error {
+	r.CreatedToken = token
+	return r.Err
+}
+
+func (r *AuthorizeTokenRegistry) DeleteAuthorizeToken(ctx kapi.Context, name string) error {
+	r.DeletedTokenName = name
+	return r.Err
+}
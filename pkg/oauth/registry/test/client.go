@@ -0,0 +1,43 @@
+package test
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// ClientRegistry is a test double implementing client.Registry.
+type ClientRegistry struct {
+	Err     error
+	Client  *api.Client
+	Clients *api.ClientList
+
+	CreatedClient     *api.Client
+	UpdatedClient     *api.Client
+	DeletedClientName string
+}
+
+func (r *ClientRegistry) ListClients(ctx kapi.Context, label, field labels.Selector) (*api.ClientList, error) {
+	return r.Clients, r.Err
+}
+
+func (r *ClientRegistry) GetClient(ctx kapi.Context, name string) (*api.Client, error) {
+	return r.Client, r.Err
+}
+
+func (r *ClientRegistry) CreateClient(ctx kapi.Context, client *api.Client) error {
+	r.CreatedClient = client
+	r.Client = client
+	return r.Err
+}
+
+func (r *ClientRegistry) UpdateClient(ctx kapi.Context, client *api.Client) error {
+	r.UpdatedClient = client
+	return r.Err
+}
+
+func (r *ClientRegistry) DeleteClient(ctx kapi.Context, name string) error {
+	r.DeletedClientName = name
+	return r.Err
+}
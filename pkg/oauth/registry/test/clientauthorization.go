@@ -0,0 +1,36 @@
+package test
+
+import (
+	kapi "github.com/GoogleCloudPlatform/kubernetes/pkg/api"
+	"github.com/GoogleCloudPlatform/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// ClientAuthorizationRegistry is a test double implementing clientauthorization.Registry.
+type ClientAuthorizationRegistry struct {
+	Err   error
+	Auth  *api.ClientAuthorization
+	Auths *api.ClientAuthorizationList
+
+	CreatedAuth     *api.ClientAuthorization
+	DeletedAuthName string
+}
+
+func (r *ClientAuthorizationRegistry) ListClientAuthorizations(ctx kapi.Context, label, field labels.Selector) (*api.ClientAuthorizationList, error) {
+	return r.Auths, r.Err
+}
+
+func (r *ClientAuthorizationRegistry) GetClientAuthorization(ctx kapi.Context, name string) (*api.ClientAuthorization, error) {
+	return r.Auth, r.Err
+}
+
+func (r *ClientAuthorizationRegistry) CreateClientAuthorization(ctx kapi.Context, auth *api.ClientAuthorization) error {
+	r.CreatedAuth = auth
+	return r.Err
+}
+
+func (r *ClientAuthorizationRegistry) DeleteClientAuthorization(ctx kapi.Context, name string) error {
+	r.DeletedAuthName = name
+	return r.Err
+}